@@ -0,0 +1,52 @@
+// Command agentx-audit inspects the hash-chained audit log pkg/audit
+// writes when security.enable_audit is set, so operators can detect
+// tampering without bringing the whole service up.
+//
+//	agentx-audit verify --file /var/log/integration-service/audit.log
+package main
+
+import (
+    "flag"
+    "fmt"
+    "os"
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/audit"
+)
+
+func main() {
+    if len(os.Args) < 2 {
+        fmt.Fprintln(os.Stderr, "usage: agentx-audit verify --file <path>")
+        os.Exit(2)
+    }
+
+    switch os.Args[1] {
+    case "verify":
+        runVerify(os.Args[2:])
+    default:
+        fmt.Fprintf(os.Stderr, "unknown subcommand %q; supported: verify\n", os.Args[1])
+        os.Exit(2)
+    }
+}
+
+func runVerify(args []string) {
+    fs := flag.NewFlagSet("verify", flag.ExitOnError)
+    path := fs.String("file", "", "path to the audit log file to verify")
+    fs.Parse(args)
+
+    if *path == "" {
+        fmt.Fprintln(os.Stderr, "--file is required")
+        os.Exit(2)
+    }
+
+    badIndex, err := audit.VerifyFile(*path)
+    if badIndex >= 0 {
+        fmt.Printf("chain broken at event %d: %v\n", badIndex, err)
+        os.Exit(1)
+    }
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "failed to verify %s: %v\n", *path, err)
+        os.Exit(1)
+    }
+
+    fmt.Println("chain verified: no tampering detected")
+}
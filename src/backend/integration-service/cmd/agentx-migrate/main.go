@@ -0,0 +1,106 @@
+// Command agentx-migrate applies, rolls back, and inspects the
+// integration service's Postgres schema migrations (see
+// pkg/database/migrations), for operators who need finer control than
+// NewPostgresDB's automatic migrate-on-startup gives them.
+//
+//	agentx-migrate up --config /etc/integration-service/config.yaml
+//	agentx-migrate down 1 --config /etc/integration-service/config.yaml
+//	agentx-migrate status --config /etc/integration-service/config.yaml
+//	agentx-migrate force 3 --config /etc/integration-service/config.yaml
+package main
+
+import (
+    "context"
+    "flag"
+    "fmt"
+    "os"
+    "strconv"
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/config"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database/migrations"
+)
+
+const defaultConfigPath = "/etc/integration-service/config.yaml"
+
+func main() {
+    if len(os.Args) < 2 {
+        fmt.Fprintln(os.Stderr, "usage: agentx-migrate <up|down N|status|force N> --config <path>")
+        os.Exit(2)
+    }
+    cmd := os.Args[1]
+    args := os.Args[2:]
+
+    var n int
+    if cmd == "down" || cmd == "force" {
+        if len(args) == 0 {
+            fmt.Fprintf(os.Stderr, "usage: agentx-migrate %s <N> --config <path>\n", cmd)
+            os.Exit(2)
+        }
+        v, err := strconv.Atoi(args[0])
+        if err != nil {
+            fmt.Fprintf(os.Stderr, "invalid version/count %q: %v\n", args[0], err)
+            os.Exit(2)
+        }
+        n = v
+        args = args[1:]
+    }
+
+    fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+    configPath := fs.String("config", defaultConfigPath, "path to configuration file")
+    fs.Parse(args)
+
+    cfg, err := config.LoadConfig(*configPath)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "failed to load configuration: %v\n", err)
+        os.Exit(1)
+    }
+
+    db, err := database.NewUnmigratedPostgresDB(&cfg.DatabaseConfig)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "failed to connect to database: %v\n", err)
+        os.Exit(1)
+    }
+
+    runner, err := migrations.NewRunner(db)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "failed to load migrations: %v\n", err)
+        os.Exit(1)
+    }
+
+    ctx := context.Background()
+
+    switch cmd {
+    case "up":
+        err = runner.Up(ctx)
+    case "down":
+        err = runner.Down(ctx, n)
+    case "status":
+        err = printStatus(ctx, runner)
+    case "force":
+        err = runner.Force(ctx, n)
+    default:
+        fmt.Fprintf(os.Stderr, "unknown subcommand %q; supported: up, down, status, force\n", cmd)
+        os.Exit(2)
+    }
+
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "agentx-migrate %s failed: %v\n", cmd, err)
+        os.Exit(1)
+    }
+}
+
+func printStatus(ctx context.Context, runner *migrations.Runner) error {
+    statuses, err := runner.Status(ctx)
+    if err != nil {
+        return err
+    }
+    for _, s := range statuses {
+        state := "pending"
+        if s.Applied {
+            state = "applied"
+        }
+        fmt.Printf("%04d  %-40s  %s\n", s.Version, s.Name, state)
+    }
+    return nil
+}
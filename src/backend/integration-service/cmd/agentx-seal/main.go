@@ -0,0 +1,63 @@
+// Command agentx-seal encrypts a plaintext value against the KEK resolved
+// from config.yaml's security.key_provider, so operators can paste the
+// result into any `encrypt:"true"` field (database.password,
+// zohocrm.client_secret, rms.api_key, ...) without the plaintext ever
+// touching disk unencrypted.
+//
+//	agentx-seal --config /etc/integration-service/config.yaml --value 's3cr3t'
+package main
+
+import (
+    "bufio"
+    "context"
+    "flag"
+    "fmt"
+    "os"
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/config"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/secrets"
+)
+
+const defaultConfigPath = "/etc/integration-service/config.yaml"
+
+func main() {
+    configPath := flag.String("config", defaultConfigPath, "path to configuration file")
+    value := flag.String("value", "", "plaintext value to seal (reads a line from stdin if omitted)")
+    aad := flag.String("aad", "", "additional authenticated data to bind the sealed value to")
+    flag.Parse()
+
+    cfg, err := config.LoadConfig(*configPath)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "failed to load configuration: %v\n", err)
+        os.Exit(1)
+    }
+
+    if cfg.SecurityConfig.KeyProvider == "" {
+        fmt.Fprintln(os.Stderr, "security.key_provider is not configured; agentx-seal has no KEK to wrap against")
+        os.Exit(1)
+    }
+
+    provider, err := secrets.NewProvider(cfg.SecurityConfig.KeyProvider)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "failed to initialize key provider: %v\n", err)
+        os.Exit(1)
+    }
+
+    plaintext := *value
+    if plaintext == "" {
+        scanner := bufio.NewScanner(os.Stdin)
+        if !scanner.Scan() {
+            fmt.Fprintln(os.Stderr, "no value provided via --value or stdin")
+            os.Exit(1)
+        }
+        plaintext = scanner.Text()
+    }
+
+    sealed, err := secrets.NewSealer(provider).Seal(context.Background(), plaintext, []byte(*aad))
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "failed to seal value: %v\n", err)
+        os.Exit(1)
+    }
+
+    fmt.Println(sealed)
+}
@@ -0,0 +1,218 @@
+// Command agentx-sync is an operator CLI for moving data from one
+// registered integration to another, e.g.:
+//
+//	agentx-sync --source zoho_crm:Leads --dest rms:Customers \
+//	    --since 24h --map name=customer_name,email=email
+//
+// It loads the same configuration and database the integration-service
+// daemon uses, resolves the source and destination connectors through
+// pkg/connectors, and drives the replication with pkg/sync.
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "flag"
+    "fmt"
+    "log/slog"
+    "os"
+    "strings"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus" // v1.16.0
+    "gorm.io/gorm"                                    // v1.25.0
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/config"
+    "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/auth"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/connectors"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/sync"
+
+    // Connector packages register themselves via init(); import for their
+    // side effects the same way the daemon's main.go does.
+    _ "github.com/yourdomain/agent-ai-platform/integration-service/internal/services/rms"
+    _ "github.com/yourdomain/agent-ai-platform/integration-service/internal/services/zoho"
+)
+
+const defaultConfigPath = "/etc/integration-service/config.yaml"
+
+func main() {
+    configPath := flag.String("config", defaultConfigPath, "path to configuration file")
+    sourceFlag := flag.String("source", "", "source integration, as service_type:resource (e.g. zoho_crm:Leads)")
+    destFlag := flag.String("dest", "", "destination integration, as service_type:resource (e.g. rms:Customers)")
+    since := flag.Duration("since", 0, "only replicate records changed within this duration of now")
+    mapFlag := flag.String("map", "", "comma-separated src=dst field renames (e.g. name=customer_name,email=email)")
+    batchSize := flag.Int("batch-size", 0, "records per page (default 100 for rms, 200 for zoho_crm)")
+    concurrency := flag.Int("concurrency", 0, "concurrent writes to the destination (default 4)")
+    dryRun := flag.Bool("dry-run", false, "map and log records without writing them or persisting a sync run")
+    jsonOutput := flag.Bool("json", false, "print the result as JSON")
+    flag.Parse()
+
+    logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+    source, err := parseEndpoint(*sourceFlag)
+    if err != nil {
+        fatal(logger, "Invalid --source", err)
+    }
+    dest, err := parseEndpoint(*destFlag)
+    if err != nil {
+        fatal(logger, "Invalid --dest", err)
+    }
+    mapping, err := parseMapping(*mapFlag)
+    if err != nil {
+        fatal(logger, "Invalid --map", err)
+    }
+
+    cfg, err := config.LoadConfig(*configPath)
+    if err != nil {
+        fatal(logger, "Failed to load configuration", err)
+    }
+
+    db, err := database.NewPostgresDB(&cfg.DatabaseConfig)
+    if err != nil {
+        fatal(logger, "Failed to connect to database", err)
+    }
+    defer database.Close(db)
+
+    // agentx-sync is a short-lived one-shot CLI, well under any credential
+    // lease's lifetime, so it connects with a plain, non-rotating pool
+    // rather than a full database.CredentialManager.
+    atomicDB := database.NewStaticAtomicDB(db)
+
+    authManager, err := auth.NewOAuthManager(cfg, atomicDB, nil, logger)
+    if err != nil {
+        fatal(logger, "Failed to initialize OAuth manager", err)
+    }
+
+    connectorMetrics := prometheus.NewCounterVec(prometheus.CounterOpts{
+        Name: "integration_sync_connector_operations_total",
+        Help: "Number of operations performed by agentx-sync connectors, by outcome.",
+    }, []string{"outcome"})
+    if err := prometheus.Register(connectorMetrics); err != nil {
+        if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+            fatal(logger, "Failed to register connector metrics", err)
+        }
+    }
+
+    ctx := context.Background()
+
+    sourceIntegration, err := findIntegration(ctx, db, source.serviceType)
+    if err != nil {
+        fatal(logger, "Failed to resolve source integration", err)
+    }
+    destIntegration, err := findIntegration(ctx, db, dest.serviceType)
+    if err != nil {
+        fatal(logger, "Failed to resolve dest integration", err)
+    }
+
+    sourceConnector, err := connectors.New(sourceIntegration, authManager, atomicDB, connectorMetrics)
+    if err != nil {
+        fatal(logger, "Failed to build source connector", err)
+    }
+    destConnector, err := connectors.New(destIntegration, authManager, atomicDB, connectorMetrics)
+    if err != nil {
+        fatal(logger, "Failed to build dest connector", err)
+    }
+
+    runner, err := sync.NewRunner(db)
+    if err != nil {
+        fatal(logger, "Failed to initialize sync runner", err)
+    }
+
+    opts := sync.Options{
+        SourceResource: source.resource,
+        DestResource:   dest.resource,
+        Mapping:        mapping,
+        BatchSize:      *batchSize,
+        Concurrency:    *concurrency,
+        DryRun:         *dryRun,
+    }
+    if *since > 0 {
+        opts.Since = time.Now().Add(-*since)
+    }
+
+    result, err := runner.Run(ctx, sourceIntegration.ID, destIntegration.ID, sourceConnector, destConnector, opts)
+    if err != nil {
+        if result != nil {
+            printResult(result, *jsonOutput)
+        }
+        fatal(logger, "Sync failed", err)
+    }
+
+    printResult(result, *jsonOutput)
+}
+
+// fatal logs msg and err at error level and exits the process, standing
+// in for zap.Logger.Fatal now that this CLI logs through slog.
+func fatal(logger *slog.Logger, msg string, err error) {
+    logger.Error(msg, "error", err)
+    os.Exit(1)
+}
+
+// endpoint is a parsed --source/--dest flag value.
+type endpoint struct {
+    serviceType string
+    resource    string
+}
+
+// parseEndpoint parses "service_type:resource" into an endpoint.
+func parseEndpoint(flagValue string) (endpoint, error) {
+    parts := strings.SplitN(flagValue, ":", 2)
+    if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+        return endpoint{}, fmt.Errorf("expected service_type:resource, got %q", flagValue)
+    }
+    return endpoint{serviceType: parts[0], resource: parts[1]}, nil
+}
+
+// parseMapping parses a comma-separated "src=dst,src2=dst2" string into a
+// field rename map. An empty input returns a nil map.
+func parseMapping(flagValue string) (map[string]string, error) {
+    if flagValue == "" {
+        return nil, nil
+    }
+
+    mapping := make(map[string]string)
+    for _, pair := range strings.Split(flagValue, ",") {
+        kv := strings.SplitN(pair, "=", 2)
+        if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+            return nil, fmt.Errorf("expected src=dst, got %q", pair)
+        }
+        mapping[kv[0]] = kv[1]
+    }
+    return mapping, nil
+}
+
+// findIntegration looks up the most recently created integration of
+// serviceType. Operators with multiple integrations of the same type should
+// disambiguate with future flags; for now the newest one wins.
+func findIntegration(ctx context.Context, db *gorm.DB, serviceType string) (*models.Integration, error) {
+    var integration models.Integration
+    err := db.WithContext(ctx).
+        Where("service_type = ?", serviceType).
+        Order("created_at DESC").
+        First(&integration).Error
+    if err != nil {
+        return nil, fmt.Errorf("no integration found for service type %q: %w", serviceType, err)
+    }
+    return &integration, nil
+}
+
+// printResult writes result to stdout, either as JSON or as a short
+// human-readable summary.
+func printResult(result *sync.Result, asJSON bool) {
+    if asJSON {
+        data, err := json.MarshalIndent(result, "", "  ")
+        if err != nil {
+            fmt.Printf("failed to marshal result: %v\n", err)
+            return
+        }
+        fmt.Println(string(data))
+        return
+    }
+
+    fmt.Printf("sync %s: %d records processed (run %s)\n", result.Status, result.RecordsProcessed, result.RunID)
+    if result.Error != "" {
+        fmt.Printf("error: %s\n", result.Error)
+    }
+}
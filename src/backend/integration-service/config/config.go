@@ -3,18 +3,23 @@
 package config
 
 import (
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"encoding/base64"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"gopkg.in/yaml.v3"
+
+	"github.com/yourdomain/agent-ai-platform/integration-service/pkg/audit"
+	"github.com/yourdomain/agent-ai-platform/integration-service/pkg/secrets"
 )
 
 // Version: gopkg.in/yaml.v3 v3.0.1
@@ -48,7 +53,15 @@ type Config struct {
 	RMSConfig      RMSConfig      `yaml:"rms" validate:"required"`
 	LogConfig      LogConfig      `yaml:"logging" validate:"required"`
 	SecurityConfig SecurityConfig  `yaml:"security" validate:"required"`
+
+	// OAuthProviders registers additional pkg/auth.ProviderRegistry entries
+	// beyond the built-in zoho_crm provider NewOAuthManager always
+	// configures from ZohoCRMConfig. Map key is the provider name, matched
+	// against models.Integration.ServiceType (e.g. "google", "salesforce",
+	// "hubspot", "slack", "github", "microsoft").
+	OAuthProviders map[string]OAuthProviderConfig `yaml:"oauth_providers" validate:"dive"`
 	mu            sync.RWMutex    // Protects concurrent access to configuration
+	keyProvider   secrets.KeyProvider // Resolved from SecurityConfig.KeyProvider; nil when using the legacy plaintext EncryptionKey
 }
 
 // DatabaseConfig holds database connection settings
@@ -57,8 +70,25 @@ type DatabaseConfig struct {
 	Port     int    `yaml:"port" validate:"required,min=1,max=65535"`
 	Name     string `yaml:"name" validate:"required"`
 	User     string `yaml:"user" validate:"required"`
-	Password string `yaml:"password" validate:"required" encrypt:"true"`
+	Password string `yaml:"password" validate:"required_if=CredentialSource static,omitempty" encrypt:"true"`
 	SSLMode  string `yaml:"ssl_mode" validate:"required,oneof=disable verify-full verify-ca require"`
+
+	// CredentialSource selects how pkg/database.CredentialManager obtains
+	// the password to connect with: "static" uses Password as-is (the
+	// default, for configs written before dynamic credentials existed),
+	// "vault" leases short-lived credentials from Vault's database secrets
+	// engine at database/creds/<CredentialRole>, and "iam" mints AWS RDS
+	// IAM authentication tokens for User using CredentialRegion.
+	CredentialSource string `yaml:"credential_source" validate:"omitempty,oneof=static vault iam"`
+	// CredentialRole is the Vault database role to request leases from;
+	// required when CredentialSource is "vault".
+	CredentialRole string `yaml:"credential_role"`
+	// CredentialRegion is the AWS region to generate RDS IAM auth tokens
+	// in; required when CredentialSource is "iam".
+	CredentialRegion string `yaml:"credential_region"`
+	// LeaseRenewBuffer is how long before a dynamic credential lease
+	// expires its CredentialManager renews it. Ignored for "static".
+	LeaseRenewBuffer time.Duration `yaml:"lease_renew_buffer"`
 }
 
 // ServerConfig contains HTTP server settings
@@ -68,6 +98,39 @@ type ServerConfig struct {
 	ReadTimeout     time.Duration `yaml:"read_timeout" validate:"required"`
 	WriteTimeout    time.Duration `yaml:"write_timeout" validate:"required"`
 	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" validate:"required"`
+
+	// GraphQLSubscriptionPort is the port pkg/graphql's
+	// integrationStatusChanged websocket listener binds to, on Host. It
+	// runs as its own net/http server alongside the fiber app rather than
+	// being mounted into it, since fasthttp (which fiber wraps) does not
+	// implement the http.Hijacker interface gorilla/websocket needs for
+	// the upgrade handshake. Zero disables GraphQL subscriptions.
+	GraphQLSubscriptionPort int `yaml:"graphql_subscription_port" validate:"omitempty,min=1,max=65535"`
+
+	// Admin configures the separate admin/metrics listener (see
+	// pkg/adminserver), which serves /metrics, /debug/pprof/*, /healthz,
+	// and /readyz away from business traffic.
+	Admin AdminConfig `yaml:"admin" validate:"required"`
+}
+
+// AdminConfig holds settings for the admin/metrics HTTP listener. It binds
+// to its own Host:Port, separate from ServerConfig's, so the operational
+// surface here (pprof, raw metrics, readiness internals) is never exposed
+// on the same port as business traffic.
+type AdminConfig struct {
+	Host string `yaml:"host" validate:"required"`
+	Port int    `yaml:"port" validate:"required,min=1,max=65535"`
+
+	// AllowedCIDRs restricts admin requests to the given source networks
+	// (e.g. a private scrape network's CIDR); empty allows any source and
+	// relies on BearerToken alone.
+	AllowedCIDRs []string `yaml:"allowed_cidrs" validate:"dive,cidr"`
+	// BearerToken, when set, is required as an "Authorization: Bearer
+	// <token>" header on every admin request, in addition to any
+	// AllowedCIDRs check. At least one of AllowedCIDRs or BearerToken
+	// should be set; pkg/adminserver logs a warning at startup if neither
+	// is, since the listener is then unauthenticated.
+	BearerToken string `yaml:"bearer_token" validate:"omitempty,min=16" encrypt:"true"`
 }
 
 // ZohoCRMConfig holds Zoho CRM integration settings
@@ -77,6 +140,14 @@ type ZohoCRMConfig struct {
 	ClientSecret string `yaml:"client_secret" validate:"required" encrypt:"true"`
 	RefreshToken string `yaml:"refresh_token" validate:"required" encrypt:"true"`
 	Timeout      time.Duration `yaml:"timeout" validate:"required"`
+
+	// MaxRPS, Burst, FailureRatio, and CoolDown drive the
+	// reliability.ReliableTransport ZohoClient makes its requests through.
+	// A zero value falls back to package reliability's own defaults.
+	MaxRPS       float64       `yaml:"max_rps"`
+	Burst        int           `yaml:"burst"`
+	FailureRatio float64       `yaml:"failure_ratio"`
+	CoolDown     time.Duration `yaml:"cool_down"`
 }
 
 // RMSConfig contains Restaurant Management System settings
@@ -85,6 +156,39 @@ type RMSConfig struct {
 	APIKey     string `yaml:"api_key" validate:"required" encrypt:"true"`
 	APIVersion string `yaml:"api_version" validate:"required"`
 	Timeout    time.Duration `yaml:"timeout" validate:"required"`
+
+	// MaxRPS, Burst, FailureRatio, and CoolDown drive the
+	// reliability.ReliableTransport RMSClient makes its requests through.
+	// A zero value falls back to package reliability's own defaults.
+	MaxRPS       float64       `yaml:"max_rps"`
+	Burst        int           `yaml:"burst"`
+	FailureRatio float64       `yaml:"failure_ratio"`
+	CoolDown     time.Duration `yaml:"cool_down"`
+}
+
+// OAuthProviderConfig configures one entry in pkg/auth's ProviderRegistry.
+// Every field maps directly onto pkg/auth.ProviderConfig; it's a separate
+// type here, rather than reusing pkg/auth.ProviderConfig directly, so this
+// package doesn't need to import pkg/auth just to describe its own YAML
+// shape.
+type OAuthProviderConfig struct {
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret" encrypt:"true"`
+	AuthURL      string   `yaml:"auth_url" validate:"omitempty,url"`
+	TokenURL     string   `yaml:"token_url" validate:"omitempty,url"`
+	RedirectURL  string   `yaml:"redirect_url"`
+	Scopes       []string `yaml:"scopes"`
+
+	// UsePKCE adds a PKCE (RFC 7636) code_challenge/code_verifier pair to
+	// the authorization-code flow.
+	UsePKCE bool `yaml:"use_pkce"`
+	// DeviceAuthURL is the RFC 8628 device-authorization endpoint; leave
+	// empty for providers that don't support the device-code flow.
+	DeviceAuthURL string `yaml:"device_auth_url" validate:"omitempty,url"`
+	// RegistrationURL is the RFC 7591 dynamic client registration
+	// endpoint. When set and ClientID is empty, OAuthManager registers a
+	// new client the first time the provider is used.
+	RegistrationURL string `yaml:"registration_url" validate:"omitempty,url"`
 }
 
 // LogConfig defines logging configuration
@@ -96,11 +200,45 @@ type LogConfig struct {
 
 // SecurityConfig holds security-related settings
 type SecurityConfig struct {
-	EncryptionKey   string   `yaml:"encryption_key" validate:"required,min=32"`
+	EncryptionKey   string   `yaml:"encryption_key" validate:"required_without=KeyProvider,omitempty,min=32"`
+	// KeyProvider is a URL selecting where encrypted fields' key material
+	// comes from: "file:///etc/...", "env://VAR", "vault://path/to/key",
+	// "aws-kms://<arn>?ciphertext=...", "gcp-kms://<key-name>?ciphertext=...".
+	// When set, EncryptionKey is
+	// ignored in favor of the resolved provider.
+	KeyProvider     string   `yaml:"key_provider"`
+	// TenantKeyProviderTemplate, when set, gives each tenant its own DEK by
+	// substituting "{tenant}" with the tenant ID and resolving the result
+	// through secrets.NewProvider, e.g. "file:///etc/agentx/tenant-{tenant}.key". This is
+	// what pkg/auth's OAuthManager seals per-tenant OAuth tokens with, so a
+	// compromised key only exposes the one tenant it belongs to. Tenants
+	// fall back to KeyProvider/EncryptionKey when this is left unset.
+	TenantKeyProviderTemplate string `yaml:"tenant_key_provider_template"`
 	EnableAudit     bool     `yaml:"enable_audit" validate:"required"`
 	AllowedOrigins  []string `yaml:"allowed_origins" validate:"required,dive,url"`
 	TLSCertPath     string   `yaml:"tls_cert_path" validate:"required,file"`
 	TLSKeyPath      string   `yaml:"tls_key_path" validate:"required,file"`
+
+	// Audit* configures pkg/audit's sinks when EnableAudit is true; at
+	// least one of AuditFilePath, AuditSyslogAddr, or AuditWebhookURL must
+	// be set. See pkg/audit.Config for what each controls.
+	AuditFilePath       string `yaml:"audit_file_path"`
+	AuditFileMaxSizeMB  int    `yaml:"audit_file_max_size_mb"`
+	AuditFileMaxAgeDays int    `yaml:"audit_file_max_age_days"`
+	AuditSyslogAddr     string `yaml:"audit_syslog_addr"`
+	AuditWebhookURL     string `yaml:"audit_webhook_url"`
+}
+
+// KeyProvider returns the key provider resolved from
+// SecurityConfig.KeyProvider (nil when using the legacy plaintext
+// EncryptionKey), so packages outside config that need to seal their own
+// values — pkg/auth's TokenCrypto, for one — share the same KEK source
+// config itself decrypts with, instead of re-resolving the key_provider
+// URL a second time.
+func (cfg *Config) KeyProvider() secrets.KeyProvider {
+	cfg.mu.RLock()
+	defer cfg.mu.RUnlock()
+	return cfg.keyProvider
 }
 
 // LoadConfig loads and validates configuration from the specified path
@@ -144,10 +282,25 @@ func LoadConfig(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("config validation failed: %w", err)
 	}
 
+	// Resolve the key provider, if one is configured, before it's needed
+	// to decrypt anything below.
+	if err := resolveKeyProvider(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to resolve key provider: %w", err)
+	}
+
+	// Stand up the audit log as soon as SecurityConfig is known, so the
+	// decrypt step below (and every later config-load) is itself audited.
+	if err := initAudit(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to initialize audit log: %w", err)
+	}
+	audit.Record(audit.Event{Actor: "system", Action: "config-load", Outcome: "success", Details: map[string]interface{}{"path": configPath}})
+
 	// Decrypt sensitive values
 	if err := decryptSensitiveValues(&cfg); err != nil {
+		audit.Record(audit.Event{Actor: "system", Action: "secret-decrypt", Outcome: "failure", Details: map[string]interface{}{"error": err.Error()}})
 		return nil, fmt.Errorf("failed to decrypt sensitive values: %w", err)
 	}
+	audit.Record(audit.Event{Actor: "system", Action: "secret-decrypt", Outcome: "success"})
 
 	return &cfg, nil
 }
@@ -203,6 +356,9 @@ func WatchConfig(cfg *Config, done chan bool) error {
 
 // setDefaults sets default values for optional configuration fields
 func setDefaults(cfg *Config) error {
+	if cfg.DatabaseConfig.CredentialSource == "" {
+		cfg.DatabaseConfig.CredentialSource = "static"
+	}
 	if cfg.ServerConfig.ReadTimeout == 0 {
 		cfg.ServerConfig.ReadTimeout = defaultTimeouts.read
 	}
@@ -221,47 +377,230 @@ func setDefaults(cfg *Config) error {
 	return nil
 }
 
-// decryptSensitiveValues decrypts configuration values marked for encryption
-func decryptSensitiveValues(cfg *Config) error {
-	key := []byte(cfg.SecurityConfig.EncryptionKey)
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return fmt.Errorf("failed to create cipher: %w", err)
+// resolveKeyProvider builds cfg's key provider from SecurityConfig.KeyProvider,
+// if one is set. With no key_provider configured, decryptSensitiveValues
+// falls back to the legacy plaintext SecurityConfig.EncryptionKey.
+func resolveKeyProvider(cfg *Config) error {
+	if cfg.SecurityConfig.KeyProvider == "" {
+		return nil
 	}
 
-	gcm, err := cipher.NewGCM(block)
+	provider, err := secrets.NewProvider(cfg.SecurityConfig.KeyProvider)
 	if err != nil {
-		return fmt.Errorf("failed to create GCM: %w", err)
+		return err
 	}
 
-	// Decrypt database password
-	if cfg.DatabaseConfig.Password != "" {
-		decrypted, err := decryptValue(cfg.DatabaseConfig.Password, gcm)
+	cfg.mu.Lock()
+	cfg.keyProvider = provider
+	cfg.mu.Unlock()
+	return nil
+}
+
+// initAudit builds pkg/audit's process-wide logger from cfg.SecurityConfig.
+// A disabled SecurityConfig.EnableAudit makes every later audit.Record
+// call a no-op.
+func initAudit(cfg *Config) error {
+	return audit.Init(audit.Config{
+		Enabled:        cfg.SecurityConfig.EnableAudit,
+		FilePath:       cfg.SecurityConfig.AuditFilePath,
+		FileMaxSizeMB:  cfg.SecurityConfig.AuditFileMaxSizeMB,
+		FileMaxAgeDays: cfg.SecurityConfig.AuditFileMaxAgeDays,
+		SyslogAddr:     cfg.SecurityConfig.AuditSyslogAddr,
+		WebhookURL:     cfg.SecurityConfig.AuditWebhookURL,
+	})
+}
+
+// RotateKey re-resolves cfg's key provider, so the next decrypt picks up a
+// newly rotated current key. Call this from a config reload path (see
+// WatchConfig) after the underlying secret backend rotates. It is a no-op
+// when no key_provider is configured.
+func RotateKey(cfg *Config) error {
+	return resolveKeyProvider(cfg)
+}
+
+// decryptSensitiveValues walks cfg's fields recursively and decrypts every
+// string field tagged `encrypt:"true"`, in place. Adding the tag to a new
+// field is enough to make it encrypted at rest; there is no per-field code
+// to update here.
+//
+// Each value is either a Sealer-produced envelope (see pkg/secrets.IsSealed)
+// or a legacy "v<N>:"-prefixed value encrypted with a single AES-GCM key,
+// so configs written before envelope encryption was introduced keep
+// decrypting correctly.
+func decryptSensitiveValues(cfg *Config) error {
+	resolver := newKeyResolver(cfg)
+	return walkEncryptedFields(reflect.ValueOf(cfg).Elem(), func(name string, field reflect.Value) error {
+		value := field.String()
+		if value == "" {
+			return nil
+		}
+		decrypted, err := resolver.decryptAny(value)
 		if err != nil {
-			return fmt.Errorf("failed to decrypt database password: %w", err)
+			return fmt.Errorf("failed to decrypt %s: %w", name, err)
 		}
-		cfg.DatabaseConfig.Password = decrypted
+		field.SetString(decrypted)
+		return nil
+	})
+}
+
+// walkEncryptedFields recursively visits every `encrypt:"true"` string
+// field reachable from v (a struct value), calling fn with its dotted field
+// path for error messages.
+func walkEncryptedFields(v reflect.Value, fn func(name string, field reflect.Value) error) error {
+	if v.Kind() != reflect.Struct {
+		return nil
 	}
 
-	// Decrypt Zoho CRM credentials
-	if cfg.ZohoCRMConfig.ClientSecret != "" {
-		decrypted, err := decryptValue(cfg.ZohoCRMConfig.ClientSecret, gcm)
-		if err != nil {
-			return fmt.Errorf("failed to decrypt Zoho client secret: %w", err)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+
+		if fv.Kind() == reflect.Struct {
+			if err := walkEncryptedFields(fv, func(name string, inner reflect.Value) error {
+				return fn(field.Name+"."+name, inner)
+			}); err != nil {
+				return err
+			}
+			continue
 		}
-		cfg.ZohoCRMConfig.ClientSecret = decrypted
+
+		if fv.Kind() == reflect.Map {
+			for _, key := range fv.MapKeys() {
+				mv := fv.MapIndex(key)
+				if mv.Kind() != reflect.Struct {
+					continue
+				}
+				// Map values aren't addressable, so walk a copy and write
+				// it back afterwards.
+				entry := reflect.New(mv.Type()).Elem()
+				entry.Set(mv)
+				if err := walkEncryptedFields(entry, func(name string, inner reflect.Value) error {
+					return fn(fmt.Sprintf("%s[%v].%s", field.Name, key, name), inner)
+				}); err != nil {
+					return err
+				}
+				fv.SetMapIndex(key, entry)
+			}
+			continue
+		}
+
+		if field.Tag.Get("encrypt") == "true" && fv.Kind() == reflect.String {
+			if err := fn(field.Name, fv); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// keyResolver picks the right AES-GCM cipher for an encrypted value's
+// key-ID prefix, caching one cipher.AEAD per key version it has already
+// resolved so a key rotation with several versions in flight doesn't
+// re-fetch the same key on every field. It also lazily builds a
+// secrets.Sealer for values that use the newer envelope-encryption format.
+type keyResolver struct {
+	ctx       context.Context
+	provider  secrets.KeyProvider
+	legacyKey []byte
+	ciphers   map[string]cipher.AEAD
+	sealer    *secrets.Sealer
+}
+
+func newKeyResolver(cfg *Config) *keyResolver {
+	return &keyResolver{
+		ctx:       context.Background(),
+		provider:  cfg.keyProvider,
+		legacyKey: []byte(cfg.SecurityConfig.EncryptionKey),
+		ciphers:   make(map[string]cipher.AEAD),
+	}
+}
+
+// decryptAny decrypts value regardless of which scheme encrypted it: a
+// Sealer envelope, if value parses as one, otherwise the legacy single-key
+// "v<N>:"-prefixed scheme.
+func (r *keyResolver) decryptAny(value string) (string, error) {
+	if secrets.IsSealed(value) {
+		if r.provider == nil {
+			return "", fmt.Errorf("value is sealed but no security.key_provider is configured")
+		}
+		if r.sealer == nil {
+			r.sealer = secrets.NewSealer(r.provider)
+		}
+		return r.sealer.Open(r.ctx, value)
+	}
+	return r.decrypt(value)
+}
+
+// decrypt decrypts value, stripping and resolving its "v<N>:" key-ID prefix
+// if it has one.
+func (r *keyResolver) decrypt(value string) (string, error) {
+	keyID, payload := splitKeyID(value)
+
+	gcm, err := r.gcmFor(keyID)
+	if err != nil {
+		return "", err
+	}
+	return decryptValue(payload, gcm)
+}
+
+// gcmFor returns the cipher for keyID, resolving and caching it on first use.
+func (r *keyResolver) gcmFor(keyID string) (cipher.AEAD, error) {
+	if gcm, ok := r.ciphers[keyID]; ok {
+		return gcm, nil
 	}
 
-	// Decrypt RMS API key
-	if cfg.RMSConfig.APIKey != "" {
-		decrypted, err := decryptValue(cfg.RMSConfig.APIKey, gcm)
+	var key []byte
+	switch {
+	case keyID == "" && r.provider == nil:
+		key = r.legacyKey
+	case r.provider != nil:
+		resolved, _, err := r.provider.Key(r.ctx, keyID)
 		if err != nil {
-			return fmt.Errorf("failed to decrypt RMS API key: %w", err)
+			return nil, fmt.Errorf("failed to resolve key %q: %w", keyID, err)
 		}
-		cfg.RMSConfig.APIKey = decrypted
+		key = resolved
+	default:
+		return nil, fmt.Errorf("encrypted value references key %q but no key provider is configured", keyID)
 	}
 
-	return nil
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	r.ciphers[keyID] = gcm
+	return gcm, nil
+}
+
+// splitKeyID splits value into its "v<N>" key-ID prefix (if any) and the
+// remaining encrypted payload. Values without a recognized prefix are
+// returned with an empty keyID.
+func splitKeyID(value string) (keyID, payload string) {
+	idx := strings.Index(value, ":")
+	if idx <= 0 || !isKeyIDPrefix(value[:idx]) {
+		return "", value
+	}
+	return value[:idx], value[idx+1:]
+}
+
+func isKeyIDPrefix(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
 }
 
 // decryptValue decrypts a single encrypted value
@@ -39,6 +39,7 @@ const (
 type Integration struct {
     ID           uuid.UUID      `gorm:"type:uuid;primary_key"`
     AgentID      uuid.UUID      `gorm:"type:uuid;not null;index"`
+    TenantID     string         `gorm:"type:varchar(100);not null;index"`
     Name         string         `gorm:"type:varchar(255);not null"`
     ServiceType  string         `gorm:"type:varchar(50);not null;index"`
     Status       string         `gorm:"type:varchar(20);not null;index"`
@@ -121,6 +122,9 @@ func (i *Integration) Validate() error {
     if i.AgentID == uuid.Nil {
         return errors.New("agent ID is required")
     }
+    if i.TenantID == "" {
+        return errors.New("tenant ID is required")
+    }
     if i.Name == "" {
         return errors.New("name is required")
     }
@@ -172,17 +176,21 @@ func (i *Integration) UpdateStatus(status string, errorMessage string) error {
     return nil
 }
 
-// validateServiceType checks if the service type is supported
+// validateServiceType checks if the service type is supported. Supported
+// types are whatever has been registered with RegisterServiceType, which
+// connector packages (see pkg/connectors) do from an init() function. This
+// keeps the models package decoupled from the set of connectors that happen
+// to be linked into a given build.
 func (i *Integration) validateServiceType() error {
-    switch i.ServiceType {
-    case ServiceTypeZohoCRM, ServiceTypeRMS:
-        return nil
-    default:
-        return fmt.Errorf("unsupported service type: %s", i.ServiceType)
+    if !IsServiceTypeRegistered(i.ServiceType) {
+        return unsupportedServiceTypeError(i.ServiceType)
     }
+    return nil
 }
 
-// validateConfig performs configuration validation
+// validateConfig performs configuration validation. It always enforces the
+// generic size/JSON-shape rules, and additionally delegates to the
+// connector-specific validator registered for i.ServiceType, if any.
 func (i *Integration) validateConfig() error {
     if len(i.Config) == 0 {
         return errors.New("configuration is required")
@@ -197,6 +205,13 @@ func (i *Integration) validateConfig() error {
         return fmt.Errorf("invalid JSON configuration: %w", err)
     }
 
+    // Delegate to the connector-specific validator, if one was registered.
+    if validateFn, ok := configValidatorFor(i.ServiceType); ok {
+        if err := validateFn(i.Config); err != nil {
+            return fmt.Errorf("%s configuration: %w", i.ServiceType, err)
+        }
+    }
+
     return nil
 }
 
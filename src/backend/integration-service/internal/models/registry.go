@@ -0,0 +1,73 @@
+package models
+
+import (
+    "encoding/json"
+    "fmt"
+    "sync"
+)
+
+// serviceTypeRegistry tracks the set of service types that have been
+// registered by a connector package, along with an optional config
+// validator supplied by that connector. It replaces the old hardcoded
+// switch in validateServiceType so that new connectors (see
+// pkg/connectors) can participate without modifying this package.
+// zoho_crm and rms are seeded here with nil validators, so they are valid
+// service types even before their connector packages' init() replaces
+// those entries.
+var serviceTypeRegistry = struct {
+    mu         sync.RWMutex
+    validators map[string]func(json.RawMessage) error
+}{
+    validators: map[string]func(json.RawMessage) error{
+        ServiceTypeZohoCRM: nil,
+        ServiceTypeRMS:     nil,
+    },
+}
+
+// RegisterServiceType makes serviceType a valid value for Integration.ServiceType.
+// validateFn is optional and, when provided, is invoked by validateConfig to
+// perform connector-specific configuration validation. Connector packages call
+// this from an init() function so that registering a connector (see
+// connectors.Register) is sufficient to make it usable by the models package.
+func RegisterServiceType(serviceType string, validateFn func(json.RawMessage) error) {
+    serviceTypeRegistry.mu.Lock()
+    defer serviceTypeRegistry.mu.Unlock()
+    serviceTypeRegistry.validators[serviceType] = validateFn
+}
+
+// IsServiceTypeRegistered reports whether serviceType has been registered.
+func IsServiceTypeRegistered(serviceType string) bool {
+    serviceTypeRegistry.mu.RLock()
+    defer serviceTypeRegistry.mu.RUnlock()
+    _, ok := serviceTypeRegistry.validators[serviceType]
+    return ok
+}
+
+// RegisteredServiceTypes returns the names of every registered service type.
+func RegisteredServiceTypes() []string {
+    serviceTypeRegistry.mu.RLock()
+    defer serviceTypeRegistry.mu.RUnlock()
+
+    types := make([]string, 0, len(serviceTypeRegistry.validators))
+    for t := range serviceTypeRegistry.validators {
+        types = append(types, t)
+    }
+    return types
+}
+
+// configValidatorFor returns the connector-specific config validator for
+// serviceType, if one was registered. The second return value is false when
+// no validator was registered (including when the service type itself is
+// unknown).
+func configValidatorFor(serviceType string) (func(json.RawMessage) error, bool) {
+    serviceTypeRegistry.mu.RLock()
+    defer serviceTypeRegistry.mu.RUnlock()
+    fn, ok := serviceTypeRegistry.validators[serviceType]
+    return fn, ok && fn != nil
+}
+
+// unsupportedServiceTypeError formats a consistent error for an unregistered
+// service type, listing what is currently registered to aid debugging.
+func unsupportedServiceTypeError(serviceType string) error {
+    return fmt.Errorf("unsupported service type: %s (registered: %v)", serviceType, RegisteredServiceTypes())
+}
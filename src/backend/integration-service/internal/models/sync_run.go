@@ -0,0 +1,34 @@
+package models
+
+import (
+    "time"
+
+    "github.com/google/uuid"
+)
+
+// SyncRun statuses.
+const (
+    SyncRunStatusRunning   = "running"
+    SyncRunStatusCompleted = "completed"
+    SyncRunStatusFailed    = "failed"
+)
+
+// SyncRun records the progress of a single cross-integration replication
+// job started by cmd/agentx-sync, so an interrupted run can be inspected
+// and resumed from Cursor rather than restarting from scratch.
+type SyncRun struct {
+    ID                  uuid.UUID `gorm:"type:uuid;primary_key"`
+    SourceIntegrationID uuid.UUID `gorm:"type:uuid;not null;index"`
+    DestIntegrationID   uuid.UUID `gorm:"type:uuid;not null;index"`
+    Cursor              string    `gorm:"type:text"`
+    RecordsProcessed    int       `gorm:"not null;default:0"`
+    Status              string    `gorm:"type:varchar(20);not null;index"`
+    ErrorMessage        string    `gorm:"type:text"`
+    CreatedAt           time.Time `gorm:"not null"`
+    UpdatedAt           time.Time `gorm:"not null"`
+}
+
+// TableName specifies the database table name for the SyncRun model.
+func (SyncRun) TableName() string {
+    return "sync_runs"
+}
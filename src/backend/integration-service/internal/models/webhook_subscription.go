@@ -0,0 +1,74 @@
+package models
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/google/uuid"
+    "gorm.io/gorm"
+)
+
+// WebhookSubscriptionTableName defines the database table name for webhook subscriptions.
+const WebhookSubscriptionTableName = "webhook_subscriptions"
+
+// WebhookSubscription represents an inbound webhook subscription tied to an
+// Integration. Its Status lifecycle mirrors Integration's: subscriptions
+// start StatusInactive, move to StatusActive once a delivery has been
+// verified and processed, and move to StatusError as soon as a delivery
+// fails signature verification or dispatch.
+type WebhookSubscription struct {
+    ID            uuid.UUID `gorm:"type:uuid;primary_key"`
+    IntegrationID uuid.UUID `gorm:"type:uuid;not null;index"`
+    Provider      string    `gorm:"type:varchar(50);not null;index"`
+    EventType     string    `gorm:"type:varchar(100);not null"`
+    Secret        string    `gorm:"type:varchar(255);not null"`
+    Status        string    `gorm:"type:varchar(20);not null;index"`
+    LastEventAt   *time.Time
+    ErrorMessage  string `gorm:"type:text"`
+    CreatedAt     time.Time `gorm:"not null"`
+    UpdatedAt     time.Time `gorm:"not null"`
+}
+
+// TableName specifies the database table name for the WebhookSubscription model.
+func (WebhookSubscription) TableName() string {
+    return WebhookSubscriptionTableName
+}
+
+// BeforeCreate generates an ID and default status if not already set.
+func (s *WebhookSubscription) BeforeCreate(tx *gorm.DB) error {
+    if s.ID == uuid.Nil {
+        s.ID = uuid.New()
+    }
+    if s.Status == "" {
+        s.Status = StatusInactive
+    }
+    if s.Provider == "" {
+        return fmt.Errorf("provider is required")
+    }
+
+    now := time.Now()
+    s.CreatedAt = now
+    s.UpdatedAt = now
+    return nil
+}
+
+// BeforeUpdate refreshes the update timestamp.
+func (s *WebhookSubscription) BeforeUpdate(tx *gorm.DB) error {
+    s.UpdatedAt = time.Now()
+    return nil
+}
+
+// MarkDelivered transitions the subscription to StatusActive after a
+// successfully processed delivery, clearing any prior error state.
+func (s *WebhookSubscription) MarkDelivered(at time.Time) {
+    s.Status = StatusActive
+    s.ErrorMessage = ""
+    s.LastEventAt = &at
+}
+
+// MarkFailed transitions the subscription to StatusError after a delivery
+// could not be verified or dispatched.
+func (s *WebhookSubscription) MarkFailed(reason string) {
+    s.Status = StatusError
+    s.ErrorMessage = reason
+}
@@ -3,6 +3,7 @@
 package rms
 
 import (
+    "bytes"
     "context"
     "encoding/json"
     "fmt"
@@ -11,80 +12,68 @@ import (
     "sync"
     "time"
 
-    "github.com/avast/retry-go/v4"     // v4.3.1
-    "github.com/sony/gobreaker"        // v2.3.0
-    "golang.org/x/time/rate"           // v0.3.0
     "github.com/prometheus/client_golang/prometheus" // v1.14.0
 
+    "github.com/yourdomain/agent-ai-platform/integration-service/config"
     "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
     "github.com/yourdomain/agent-ai-platform/integration-service/pkg/auth"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/heartbeat"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/reliability"
 )
 
 const (
     defaultTimeout = 30 * time.Second
-    maxRetries    = 3
     baseAPIPath   = "/api/v1"
-    defaultRateLimit = 100
-    circuitBreakerTimeout = 60 * time.Second
-    maxConcurrentRequests = 50
 )
 
 // RMSClient provides an enterprise-grade interface for RMS API interactions
 type RMSClient struct {
-    httpClient  *http.Client
-    integration *models.Integration
-    authManager *auth.OAuthManager
-    baseURL     string
-    rateLimiter *rate.Limiter
-    breaker     *gobreaker.CircuitBreaker
-    metrics     *prometheus.CounterVec
-    requestPool *sync.Pool
+    httpClient    *http.Client
+    integration   *models.Integration
+    authenticator auth.Authenticator
+    db            *database.AtomicDB
+    baseURL       string
+    metrics       *prometheus.CounterVec
+    requestPool   *sync.Pool
 }
 
-// NewRMSClient creates a new RMS client with enterprise features
-func NewRMSClient(integration *models.Integration, authManager *auth.OAuthManager, metrics *prometheus.CounterVec) (*RMSClient, error) {
-    if integration == nil || authManager == nil || metrics == nil {
+// NewRMSClient creates a new RMS client with enterprise features.
+// authenticator applies whichever scheme this integration's Config.Auth
+// selects (OAuth2, API key, HMAC signing, ...) to every outgoing request.
+// db is used to record heartbeat checkins after successful requests; see
+// pkg/heartbeat. rel configures the reliability.ReliableTransport the
+// client sends requests through; a zero value falls back to package
+// reliability's own defaults.
+func NewRMSClient(integration *models.Integration, authenticator auth.Authenticator, db *database.AtomicDB, metrics *prometheus.CounterVec, rel reliability.Config) (*RMSClient, error) {
+    if integration == nil || authenticator == nil || db == nil || metrics == nil {
         return nil, fmt.Errorf("all dependencies must be provided")
     }
 
     // Parse configuration
-    var config struct {
+    var cfg struct {
         BaseURL string `json:"base_url"`
     }
-    if err := json.Unmarshal(integration.Config, &config); err != nil {
+    if err := json.Unmarshal(integration.Config, &cfg); err != nil {
         return nil, fmt.Errorf("invalid integration configuration: %w", err)
     }
 
-    // Configure HTTP client with timeouts and connection pooling
-    transport := &http.Transport{
+    // Configure HTTP client with timeouts, connection pooling, and the
+    // shared reliability transport (rate limiting, circuit breaking, retries).
+    transport := reliability.NewReliableTransport(fmt.Sprintf("rms-client-%s", integration.ID), rel, &http.Transport{
         MaxIdleConns:        100,
         MaxConnsPerHost:     100,
         MaxIdleConnsPerHost: 100,
         IdleConnTimeout:     90 * time.Second,
-    }
+    }, func(from, to string) {
+        metrics.WithLabelValues("circuit_breaker_state_change").Inc()
+    })
 
     client := &http.Client{
         Timeout:   defaultTimeout,
         Transport: transport,
     }
 
-    // Configure rate limiter
-    limiter := rate.NewLimiter(rate.Limit(defaultRateLimit), defaultRateLimit)
-
-    // Configure circuit breaker
-    breakerSettings := gobreaker.Settings{
-        Name:        fmt.Sprintf("rms-client-%s", integration.ID),
-        MaxRequests: uint32(maxConcurrentRequests),
-        Timeout:     circuitBreakerTimeout,
-        ReadyToTrip: func(counts gobreaker.Counts) bool {
-            failureRatio := float64(counts.TotalFailures) / float64(counts.Requests)
-            return counts.Requests >= 10 && failureRatio >= 0.6
-        },
-        OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
-            metrics.WithLabelValues("circuit_breaker_state_change").Inc()
-        },
-    }
-
     // Initialize request object pool
     requestPool := &sync.Pool{
         New: func() interface{} {
@@ -93,14 +82,13 @@ func NewRMSClient(integration *models.Integration, authManager *auth.OAuthManage
     }
 
     rmsClient := &RMSClient{
-        httpClient:  client,
-        integration: integration,
-        authManager: authManager,
-        baseURL:     config.BaseURL,
-        rateLimiter: limiter,
-        breaker:     gobreaker.NewCircuitBreaker(breakerSettings),
-        metrics:     metrics,
-        requestPool: requestPool,
+        httpClient:    client,
+        integration:   integration,
+        authenticator: authenticator,
+        db:            db,
+        baseURL:       cfg.BaseURL,
+        metrics:       metrics,
+        requestPool:   requestPool,
     }
 
     // Perform initial health check
@@ -111,6 +99,17 @@ func NewRMSClient(integration *models.Integration, authManager *auth.OAuthManage
     return rmsClient, nil
 }
 
+// reliabilityConfig maps an RMSConfig's reliability knobs onto
+// reliability.Config for NewReliableTransport.
+func reliabilityConfig(cfg config.RMSConfig) reliability.Config {
+    return reliability.Config{
+        MaxRPS:       cfg.MaxRPS,
+        Burst:        cfg.Burst,
+        FailureRatio: cfg.FailureRatio,
+        CoolDown:     cfg.CoolDown,
+    }
+}
+
 // GetOrders retrieves orders from the RMS system with comprehensive error handling
 func (c *RMSClient) GetOrders(ctx context.Context, filters map[string]interface{}) ([]Order, error) {
     startTime := time.Now()
@@ -118,69 +117,154 @@ func (c *RMSClient) GetOrders(ctx context.Context, filters map[string]interface{
         c.metrics.WithLabelValues("get_orders_duration_seconds").Add(time.Since(startTime).Seconds())
     }()
 
-    // Check rate limit
-    if err := c.rateLimiter.Wait(ctx); err != nil {
-        c.metrics.WithLabelValues("rate_limit_exceeded").Inc()
-        return nil, fmt.Errorf("rate limit exceeded: %w", err)
-    }
-
-    // Get authentication token
-    token, err := c.authManager.GetToken(ctx, c.integration.ID)
-    if err != nil {
-        c.metrics.WithLabelValues("auth_error").Inc()
-        return nil, fmt.Errorf("failed to get auth token: %w", err)
-    }
-
     // Prepare request
     url := fmt.Sprintf("%s%s/orders", c.baseURL, baseAPIPath)
     req := c.requestPool.Get().(*http.Request)
     defer c.requestPool.Put(req)
 
     req.URL.RawQuery = buildQueryString(filters)
-    req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
     req.Header.Set("Content-Type", "application/json")
 
-    var orders []Order
-    err = retry.Do(
-        func() error {
-            return c.breaker.Execute(func() error {
-                resp, err := c.httpClient.Do(req)
-                if err != nil {
-                    c.metrics.WithLabelValues("request_error").Inc()
-                    return fmt.Errorf("request failed: %w", err)
-                }
-                defer resp.Body.Close()
-
-                if resp.StatusCode != http.StatusOK {
-                    c.metrics.WithLabelValues("api_error").Inc()
-                    return fmt.Errorf("API returned status %d", resp.StatusCode)
-                }
-
-                body, err := io.ReadAll(resp.Body)
-                if err != nil {
-                    return fmt.Errorf("failed to read response: %w", err)
-                }
-
-                if err := json.Unmarshal(body, &orders); err != nil {
-                    return fmt.Errorf("failed to parse response: %w", err)
-                }
-
-                c.metrics.WithLabelValues("success").Inc()
-                return nil
-            })
-        },
-        retry.Attempts(maxRetries),
-        retry.DelayType(retry.BackOffDelay),
-        retry.Context(ctx),
-    )
+    // Apply the integration's authentication scheme
+    if err := c.authenticator.Apply(ctx, req); err != nil {
+        c.metrics.WithLabelValues("auth_error").Inc()
+        return nil, fmt.Errorf("failed to apply authentication: %w", err)
+    }
+
+    // Rate limiting, circuit breaking, and retries all happen inside
+    // c.httpClient's reliability.ReliableTransport.
+    resp, err := c.httpClient.Do(req)
+    if err != nil {
+        c.metrics.WithLabelValues("request_error").Inc()
+        return nil, fmt.Errorf("failed to get orders: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        c.metrics.WithLabelValues("api_error").Inc()
+        return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+    }
 
+    body, err := io.ReadAll(resp.Body)
     if err != nil {
-        return nil, fmt.Errorf("failed to get orders after %d attempts: %w", maxRetries, err)
+        return nil, fmt.Errorf("failed to read response: %w", err)
+    }
+
+    var orders []Order
+    if err := json.Unmarshal(body, &orders); err != nil {
+        return nil, fmt.Errorf("failed to parse response: %w", err)
+    }
+    c.metrics.WithLabelValues("success").Inc()
+
+    if err := heartbeat.Checkin(ctx, c.db, c.integration.ID); err != nil {
+        c.metrics.WithLabelValues("heartbeat_error").Inc()
     }
 
     return orders, nil
 }
 
+// ListResources retrieves a page of an arbitrary RMS resource (orders,
+// customers, menu items, ...), unlike GetOrders which is specialized to the
+// Order type. It exists for pkg/sync, which needs to move records between
+// connectors without knowing their concrete Go types.
+func (c *RMSClient) ListResources(ctx context.Context, resource string, filters map[string]interface{}) ([]map[string]interface{}, error) {
+    startTime := time.Now()
+    defer func() {
+        c.metrics.WithLabelValues("list_resources_duration_seconds").Add(time.Since(startTime).Seconds())
+    }()
+
+    reqURL := fmt.Sprintf("%s%s/%s?%s", c.baseURL, baseAPIPath, resource, buildQueryString(filters))
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+    if err := c.authenticator.Apply(ctx, req); err != nil {
+        c.metrics.WithLabelValues("auth_error").Inc()
+        return nil, fmt.Errorf("failed to apply authentication: %w", err)
+    }
+
+    // Rate limiting, circuit breaking, and retries all happen inside
+    // c.httpClient's reliability.ReliableTransport.
+    resp, err := c.httpClient.Do(req)
+    if err != nil {
+        c.metrics.WithLabelValues("request_error").Inc()
+        return nil, fmt.Errorf("failed to list %s: %w", resource, err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        c.metrics.WithLabelValues("api_error").Inc()
+        return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+    }
+
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read response: %w", err)
+    }
+
+    var records []map[string]interface{}
+    if err := json.Unmarshal(body, &records); err != nil {
+        return nil, fmt.Errorf("failed to parse response: %w", err)
+    }
+    c.metrics.WithLabelValues("success").Inc()
+
+    if err := heartbeat.Checkin(ctx, c.db, c.integration.ID); err != nil {
+        c.metrics.WithLabelValues("heartbeat_error").Inc()
+    }
+
+    return records, nil
+}
+
+// CreateResource creates a single record of an arbitrary RMS resource.
+func (c *RMSClient) CreateResource(ctx context.Context, resource string, record map[string]interface{}) (map[string]interface{}, error) {
+    startTime := time.Now()
+    defer func() {
+        c.metrics.WithLabelValues("create_resource_duration_seconds").Add(time.Since(startTime).Seconds())
+    }()
+
+    body, err := json.Marshal(record)
+    if err != nil {
+        return nil, fmt.Errorf("failed to marshal record: %w", err)
+    }
+
+    reqURL := fmt.Sprintf("%s%s/%s", c.baseURL, baseAPIPath, resource)
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewReader(body))
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+    req.Header.Set("Content-Type", "application/json")
+    if err := c.authenticator.Apply(ctx, req); err != nil {
+        c.metrics.WithLabelValues("auth_error").Inc()
+        return nil, fmt.Errorf("failed to apply authentication: %w", err)
+    }
+
+    // Rate limiting, circuit breaking, and retries all happen inside
+    // c.httpClient's reliability.ReliableTransport.
+    resp, err := c.httpClient.Do(req)
+    if err != nil {
+        c.metrics.WithLabelValues("request_error").Inc()
+        return nil, fmt.Errorf("failed to create %s: %w", resource, err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+        c.metrics.WithLabelValues("api_error").Inc()
+        return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+    }
+
+    respBody, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read response: %w", err)
+    }
+    var created map[string]interface{}
+    if err := json.Unmarshal(respBody, &created); err != nil {
+        return nil, fmt.Errorf("failed to parse response: %w", err)
+    }
+    c.metrics.WithLabelValues("success").Inc()
+
+    return created, nil
+}
+
 // HealthCheck performs a health check of the RMS API
 func (c *RMSClient) HealthCheck(ctx context.Context) error {
     url := fmt.Sprintf("%s%s/health", c.baseURL, baseAPIPath)
@@ -0,0 +1,69 @@
+package rms
+
+import (
+    "encoding/json"
+    "fmt"
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/auth"
+)
+
+// configSchemaVersion is bumped whenever Config gains or changes fields in a
+// way that affects how stored configuration should be interpreted.
+const configSchemaVersion = 1
+
+// Config is the RMS connector's integration.Config schema. It implements
+// models.IntegrationConfig so it can be validated generically by the
+// connector registry.
+//
+// RMS variants in the wild don't all speak OAuth: some sign requests with a
+// shared HMAC secret, some sit behind a static API key, some terminate mTLS
+// directly. Auth carries whichever scheme this integration uses; an empty
+// Auth defaults to OAuth2, matching integrations created before schemes were
+// pluggable.
+type Config struct {
+    BaseURL string            `json:"base_url"`
+    Auth    auth.SchemeConfig `json:"auth,omitempty"`
+}
+
+// Validate checks that the RMS configuration is usable.
+func (c Config) Validate() error {
+    if c.BaseURL == "" {
+        return fmt.Errorf("base_url is required")
+    }
+    if err := c.Auth.Validate(); err != nil {
+        return err
+    }
+    return nil
+}
+
+// GetSchema describes the fields Config expects.
+func (c Config) GetSchema() map[string]interface{} {
+    return map[string]interface{}{
+        "base_url": "string, required",
+        "auth":     "object, optional, see auth.SchemeConfig; defaults to oauth2",
+    }
+}
+
+// GetVersion returns the schema version this Config struct implements.
+func (c Config) GetVersion() int {
+    return configSchemaVersion
+}
+
+// parseConfig unmarshals and validates a raw integration config payload.
+func parseConfig(raw json.RawMessage) (Config, error) {
+    var cfg Config
+    if err := json.Unmarshal(raw, &cfg); err != nil {
+        return Config{}, fmt.Errorf("invalid RMS configuration: %w", err)
+    }
+    if err := cfg.Validate(); err != nil {
+        return Config{}, err
+    }
+    return cfg, nil
+}
+
+// validateConfig adapts parseConfig to the func(json.RawMessage) error shape
+// expected by models.RegisterServiceType.
+func validateConfig(raw json.RawMessage) error {
+    _, err := parseConfig(raw)
+    return err
+}
@@ -0,0 +1,132 @@
+package rms
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/auth"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/connectors"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/heartbeat"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/webhooks"
+)
+
+// expectedCheckinInterval is how often a healthy RMS integration is expected
+// to poll for orders.
+const expectedCheckinInterval = 60 * time.Second
+
+// webhookProvider is the {provider} path segment RMS's inbound webhook
+// deliveries arrive under; see pkg/webhooks.Receiver.
+const webhookProvider = "rms"
+
+func init() {
+    models.RegisterServiceType(models.ServiceTypeRMS, validateConfig)
+    connectors.Register(models.ServiceTypeRMS, NewConnector)
+    heartbeat.RegisterInterval(models.ServiceTypeRMS, expectedCheckinInterval)
+    webhooks.RegisterVerifier(webhookProvider, webhooks.HMACSHA256Verifier("X-RMS-Webhook-Signature"))
+}
+
+// rmsConnector adapts RMSClient to the connectors.Connector interface.
+type rmsConnector struct {
+    client *RMSClient
+}
+
+// NewConnector constructs an RMS connectors.Connector, satisfying
+// connectors.Factory. authManager's Config always supplies the RMSConfig
+// reliability settings; its OAuth tokens are only used when the
+// integration's Config.Auth selects (or defaults to) OAuth2, while other
+// schemes build their Authenticator directly from the stored config.
+func NewConnector(integration *models.Integration, authManager *auth.OAuthManager, db *database.AtomicDB, metrics *prometheus.CounterVec) (connectors.Connector, error) {
+    cfg, err := parseConfig(integration.Config)
+    if err != nil {
+        return nil, err
+    }
+
+    authenticator, err := cfg.Auth.Build(authManager, integration.ID)
+    if err != nil {
+        return nil, fmt.Errorf("rms connector: %w", err)
+    }
+
+    client, err := NewRMSClient(integration, authenticator, db, metrics, reliabilityConfig(authManager.Config().RMSConfig))
+    if err != nil {
+        return nil, err
+    }
+    return &rmsConnector{client: client}, nil
+}
+
+// Name implements connectors.Connector.
+func (c *rmsConnector) Name() string {
+    return models.ServiceTypeRMS
+}
+
+// ConfigSchema implements connectors.Connector.
+func (c *rmsConnector) ConfigSchema() models.IntegrationConfig {
+    return Config{}
+}
+
+// HealthCheck implements connectors.Connector.
+func (c *rmsConnector) HealthCheck(ctx context.Context) error {
+    return c.client.HealthCheck(ctx)
+}
+
+// Sync implements connectors.Connector by pulling the current order set,
+// which also has the side effect of confirming the integration is live.
+func (c *rmsConnector) Sync(ctx context.Context) error {
+    _, err := c.client.GetOrders(ctx, nil)
+    return err
+}
+
+// Invoke implements connectors.Connector, dispatching to the handful of
+// actions the RMS client exposes.
+func (c *rmsConnector) Invoke(ctx context.Context, action string, params map[string]interface{}) (interface{}, error) {
+    switch action {
+    case "get_orders":
+        return c.client.GetOrders(ctx, params)
+    default:
+        return nil, fmt.Errorf("rms connector: unsupported action: %s", action)
+    }
+}
+
+// defaultPageSize bounds how many records ListRecords asks the RMS API for
+// in a single page when the caller does not specify one.
+const defaultPageSize = 100
+
+// ListRecords implements connectors.Reader.
+func (c *rmsConnector) ListRecords(ctx context.Context, resource string, opts connectors.ListOptions) (connectors.ListResult, error) {
+    limit := opts.Limit
+    if limit <= 0 {
+        limit = defaultPageSize
+    }
+    offset := 0
+    if opts.Cursor != "" {
+        if _, err := fmt.Sscanf(opts.Cursor, "%d", &offset); err != nil {
+            return connectors.ListResult{}, fmt.Errorf("rms connector: invalid cursor %q: %w", opts.Cursor, err)
+        }
+    }
+
+    filters := map[string]interface{}{"limit": limit, "offset": offset}
+    if !opts.Since.IsZero() {
+        filters["updated_since"] = opts.Since.Format(time.RFC3339)
+    }
+
+    records, err := c.client.ListResources(ctx, resource, filters)
+    if err != nil {
+        return connectors.ListResult{}, err
+    }
+
+    done := len(records) < limit
+    result := connectors.ListResult{Records: records, Done: done}
+    if !done {
+        result.NextCursor = fmt.Sprintf("%d", offset+len(records))
+    }
+    return result, nil
+}
+
+// CreateRecord implements connectors.Writer.
+func (c *rmsConnector) CreateRecord(ctx context.Context, resource string, record map[string]interface{}) (map[string]interface{}, error) {
+    return c.client.CreateResource(ctx, resource, record)
+}
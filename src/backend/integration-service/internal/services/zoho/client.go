@@ -8,88 +8,93 @@ import (
     "encoding/json"
     "fmt"
     "io"
+    "log/slog"
     "net/http"
     "time"
 
     "github.com/google/uuid"                           // v1.3.0
-    "github.com/sony/gobreaker"                       // v0.5.0
-    "golang.org/x/time/rate"                          // v0.3.0
-    "go.uber.org/zap"                                 // v1.24.0
     "github.com/prometheus/client_golang/prometheus"   // v1.14.0
 
+    "github.com/yourdomain/agent-ai-platform/integration-service/config"
     "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/audit"
     "github.com/yourdomain/agent-ai-platform/integration-service/pkg/auth"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/heartbeat"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/reliability"
 )
 
 const (
     baseURL = "https://www.zohoapis.com/crm/v3"
     defaultTimeout = 30 * time.Second
-    maxRetries = 3
-    rateLimitDelay = 100 * time.Millisecond
 )
 
 // ZohoClient provides an enhanced Zoho CRM client with circuit breaking,
 // rate limiting, and comprehensive monitoring capabilities.
 type ZohoClient struct {
     httpClient       *http.Client
-    authManager      *auth.OAuthManager
+    authenticator    auth.Authenticator
     integrationID    uuid.UUID
-    breaker          *gobreaker.CircuitBreaker
-    rateLimiter      *rate.Limiter
-    logger           *zap.Logger
+    db               *database.AtomicDB
+    logger           *slog.Logger
     metricsCollector *prometheus.CounterVec
 }
 
 // NewZohoClient creates a new ZohoClient instance with enhanced features.
-func NewZohoClient(authManager *auth.OAuthManager, integrationID uuid.UUID, collector *prometheus.CounterVec, logger *zap.Logger) *ZohoClient {
-    // Initialize HTTP client with timeout
+// authenticator applies this integration's OAuth bearer token to every
+// outgoing request; Zoho CRM only supports OAuth2, so it is always an
+// *auth.OAuthAuthenticator in practice, but the client only depends on the
+// narrower Authenticator interface. db is used to record heartbeat
+// checkins after successful requests; see pkg/heartbeat. rel configures the
+// reliability.ReliableTransport the client sends requests through; a zero
+// value falls back to package reliability's own defaults.
+func NewZohoClient(authenticator auth.Authenticator, integrationID uuid.UUID, db *database.AtomicDB, collector *prometheus.CounterVec, logger *slog.Logger, rel reliability.Config) *ZohoClient {
+    transport := reliability.NewReliableTransport("zoho-api", rel, &http.Transport{
+        MaxIdleConns:        100,
+        MaxIdleConnsPerHost: 100,
+        IdleConnTimeout:     90 * time.Second,
+    }, func(from, to string) {
+        logger.Warn("Circuit breaker state changed",
+            "name", "zoho-api",
+            "from", from,
+            "to", to,
+        )
+    })
+
     httpClient := &http.Client{
-        Timeout: defaultTimeout,
-        Transport: &http.Transport{
-            MaxIdleConns:        100,
-            MaxIdleConnsPerHost: 100,
-            IdleConnTimeout:     90 * time.Second,
-        },
-    }
-
-    // Configure circuit breaker
-    breakerSettings := gobreaker.Settings{
-        Name:        "zoho-api",
-        MaxRequests: 100,
-        Interval:    time.Minute,
-        Timeout:     60 * time.Second,
-        ReadyToTrip: func(counts gobreaker.Counts) bool {
-            failureRatio := float64(counts.TotalFailures) / float64(counts.Requests)
-            return counts.Requests >= 10 && failureRatio >= 0.6
-        },
-        OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
-            logger.Warn("Circuit breaker state changed",
-                zap.String("name", name),
-                zap.String("from", from.String()),
-                zap.String("to", to.String()),
-            )
-        },
+        Timeout:   defaultTimeout,
+        Transport: transport,
     }
 
     return &ZohoClient{
         httpClient:       httpClient,
-        authManager:      authManager,
+        authenticator:    authenticator,
         integrationID:    integrationID,
-        breaker:          gobreaker.NewCircuitBreaker(breakerSettings),
-        rateLimiter:      rate.NewLimiter(rate.Every(100*time.Millisecond), 10),
+        db:               db,
         logger:           logger,
         metricsCollector: collector,
     }
 }
 
+// reliabilityConfig maps a ZohoCRMConfig's reliability knobs onto
+// reliability.Config for NewReliableTransport.
+func reliabilityConfig(cfg config.ZohoCRMConfig) reliability.Config {
+    return reliability.Config{
+        MaxRPS:       cfg.MaxRPS,
+        Burst:        cfg.Burst,
+        FailureRatio: cfg.FailureRatio,
+        CoolDown:     cfg.CoolDown,
+    }
+}
+
 // GetRecords retrieves records from Zoho CRM with enhanced error handling and monitoring.
 func (c *ZohoClient) GetRecords(ctx context.Context, module string, filters map[string]interface{}) ([]map[string]interface{}, error) {
     startTime := time.Now()
     defer func() {
         c.metricsCollector.WithLabelValues("get_records", module).Inc()
         c.logger.Debug("GetRecords completed",
-            zap.String("module", module),
-            zap.Duration("duration", time.Since(startTime)),
+            "module", module,
+            "duration", time.Since(startTime),
         )
     }()
 
@@ -123,65 +128,145 @@ func (c *ZohoClient) GetRecords(ctx context.Context, module string, filters map[
         return nil, fmt.Errorf("failed to parse response: %w", err)
     }
 
+    if err := heartbeat.Checkin(ctx, c.db, c.integrationID); err != nil {
+        c.metricsCollector.WithLabelValues("get_records", "heartbeat_error").Inc()
+    }
+
     return response.Data, nil
 }
 
-// doRequest executes HTTP requests with circuit breaking, rate limiting, and retries.
+// CreateRecord creates a single record in the given Zoho CRM module.
+func (c *ZohoClient) CreateRecord(ctx context.Context, module string, record map[string]interface{}) (map[string]interface{}, error) {
+    startTime := time.Now()
+    defer func() {
+        c.metricsCollector.WithLabelValues("create_record", module).Inc()
+        c.logger.Debug("CreateRecord completed",
+            "module", module,
+            "duration", time.Since(startTime),
+        )
+    }()
+
+    payload, err := json.Marshal(struct {
+        Data []map[string]interface{} `json:"data"`
+    }{Data: []map[string]interface{}{record}})
+    if err != nil {
+        return nil, fmt.Errorf("failed to marshal record: %w", err)
+    }
+
+    url := fmt.Sprintf("%s/%s", baseURL, module)
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+
+    responseBody, err := c.doRequest(ctx, req)
+    if err != nil {
+        return nil, fmt.Errorf("request failed: %w", err)
+    }
+
+    var response struct {
+        Data []map[string]interface{} `json:"data"`
+    }
+    if err := json.Unmarshal(responseBody, &response); err != nil {
+        return nil, fmt.Errorf("failed to parse response: %w", err)
+    }
+    if len(response.Data) == 0 {
+        return nil, fmt.Errorf("zoho CRM returned no data for created record")
+    }
+
+    return response.Data[0], nil
+}
+
+// HealthCheck verifies that Zoho CRM is reachable and the stored credentials
+// are still accepted by attempting a minimal records lookup.
+func (c *ZohoClient) HealthCheck(ctx context.Context) error {
+    if _, err := c.GetRecords(ctx, "org", nil); err != nil {
+        return fmt.Errorf("zoho health check failed: %w", err)
+    }
+    return nil
+}
+
+// doRequest applies authentication and executes req. Rate limiting,
+// circuit breaking, and retries all happen inside c.httpClient's
+// reliability.ReliableTransport, so this only has to worry about the
+// request/response shape Zoho CRM expects. Every outcome, success or
+// failure, is recorded to pkg/audit.
 func (c *ZohoClient) doRequest(ctx context.Context, req *http.Request) ([]byte, error) {
-    // Execute through circuit breaker
-    result, err := c.breaker.Execute(func() (interface{}, error) {
-        // Apply rate limiting
-        if err := c.rateLimiter.Wait(ctx); err != nil {
-            return nil, fmt.Errorf("rate limit exceeded: %w", err)
-        }
+    if err := c.authenticator.Apply(ctx, req); err != nil {
+        return nil, fmt.Errorf("failed to apply authentication: %w", err)
+    }
+    req.Header.Set("Content-Type", "application/json")
 
-        // Get OAuth token
-        token, err := c.authManager.GetToken(ctx, c.integrationID)
-        if err != nil {
-            return nil, fmt.Errorf("failed to get token: %w", err)
-        }
+    requestFingerprint := fingerprintRequestBody(req)
 
-        // Add authorization header
-        req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
-        req.Header.Set("Content-Type", "application/json")
-
-        // Execute request with retries
-        var resp *http.Response
-        for attempt := 1; attempt <= maxRetries; attempt++ {
-            resp, err = c.httpClient.Do(req)
-            if err == nil {
-                break
-            }
-
-            if attempt == maxRetries {
-                return nil, fmt.Errorf("max retries exceeded: %w", err)
-            }
-
-            select {
-            case <-ctx.Done():
-                return nil, ctx.Err()
-            case <-time.After(rateLimitDelay * time.Duration(attempt)):
-                continue
-            }
-        }
-        defer resp.Body.Close()
+    resp, err := c.httpClient.Do(req)
+    if err != nil {
+        c.recordAPICall(req, requestFingerprint, "", 0, err)
+        return nil, fmt.Errorf("request failed: %w", err)
+    }
+    defer resp.Body.Close()
 
-        // Handle response
-        body, err := io.ReadAll(resp.Body)
-        if err != nil {
-            return nil, fmt.Errorf("failed to read response: %w", err)
-        }
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        c.recordAPICall(req, requestFingerprint, "", resp.StatusCode, err)
+        return nil, fmt.Errorf("failed to read response: %w", err)
+    }
 
-        if resp.StatusCode >= 400 {
-            return nil, fmt.Errorf("API error: status=%d, body=%s", resp.StatusCode, string(body))
-        }
+    if resp.StatusCode >= 400 {
+        apiErr := fmt.Errorf("API error: status=%d, body=%s", resp.StatusCode, string(body))
+        c.recordAPICall(req, requestFingerprint, audit.Fingerprint(body), resp.StatusCode, apiErr)
+        return nil, apiErr
+    }
 
-        return body, nil
-    })
+    c.recordAPICall(req, requestFingerprint, audit.Fingerprint(body), resp.StatusCode, nil)
+    return body, nil
+}
 
+// fingerprintRequestBody reads req's body through GetBody (leaving req
+// itself untouched for the caller) and returns its audit.Fingerprint, or
+// "" for a bodyless request (GET) or one GetBody can't be read from.
+func fingerprintRequestBody(req *http.Request) string {
+    if req.GetBody == nil {
+        return ""
+    }
+    rc, err := req.GetBody()
+    if err != nil {
+        return ""
+    }
+    defer rc.Close()
+    data, err := io.ReadAll(rc)
     if err != nil {
-        return nil, err
+        return ""
+    }
+    return audit.Fingerprint(data)
+}
+
+// recordAPICall writes an audit.Event summarizing one Zoho CRM API call; a
+// no-op unless SecurityConfig.EnableAudit is set. Only fingerprints of the
+// request/response bodies are recorded, never the bodies themselves, so
+// the audit log can later corroborate "this exact request/response pair
+// happened" without holding anything sensitive.
+func (c *ZohoClient) recordAPICall(req *http.Request, requestFingerprint, responseFingerprint string, statusCode int, callErr error) {
+    outcome := "success"
+    details := map[string]interface{}{
+        "method":               req.Method,
+        "path":                 req.URL.Path,
+        "status_code":          statusCode,
+        "request_fingerprint":  requestFingerprint,
+        "response_fingerprint": responseFingerprint,
+    }
+    if callErr != nil {
+        outcome = "failure"
+        details["error"] = callErr.Error()
     }
 
-    return result.([]byte), nil
+    if err := audit.Record(audit.Event{
+        Actor:         "zoho-client",
+        IntegrationID: c.integrationID.String(),
+        Action:        "api-call",
+        Outcome:       outcome,
+        Details:       details,
+    }); err != nil {
+        c.logger.Warn("failed to record audit event", "error", err)
+    }
 }
\ No newline at end of file
@@ -0,0 +1,66 @@
+package zoho
+
+import (
+    "encoding/json"
+    "fmt"
+)
+
+// configSchemaVersion is bumped whenever Config gains or changes fields in a
+// way that affects how stored configuration should be interpreted.
+const configSchemaVersion = 1
+
+// Config is the Zoho CRM connector's integration.Config schema. It
+// implements models.IntegrationConfig so it can be validated generically by
+// the connector registry.
+type Config struct {
+    ClientID     string `json:"client_id"`
+    ClientSecret string `json:"client_secret"`
+    RefreshToken string `json:"refresh_token"`
+}
+
+// Validate checks that the Zoho CRM configuration is usable.
+func (c Config) Validate() error {
+    if c.ClientID == "" {
+        return fmt.Errorf("client_id is required")
+    }
+    if c.ClientSecret == "" {
+        return fmt.Errorf("client_secret is required")
+    }
+    if c.RefreshToken == "" {
+        return fmt.Errorf("refresh_token is required")
+    }
+    return nil
+}
+
+// GetSchema describes the fields Config expects.
+func (c Config) GetSchema() map[string]interface{} {
+    return map[string]interface{}{
+        "client_id":     "string, required",
+        "client_secret": "string, required, encrypted at rest",
+        "refresh_token": "string, required, encrypted at rest",
+    }
+}
+
+// GetVersion returns the schema version this Config struct implements.
+func (c Config) GetVersion() int {
+    return configSchemaVersion
+}
+
+// parseConfig unmarshals and validates a raw integration config payload.
+func parseConfig(raw json.RawMessage) (Config, error) {
+    var cfg Config
+    if err := json.Unmarshal(raw, &cfg); err != nil {
+        return Config{}, fmt.Errorf("invalid Zoho CRM configuration: %w", err)
+    }
+    if err := cfg.Validate(); err != nil {
+        return Config{}, err
+    }
+    return cfg, nil
+}
+
+// validateConfig adapts parseConfig to the func(json.RawMessage) error shape
+// expected by models.RegisterServiceType.
+func validateConfig(raw json.RawMessage) error {
+    _, err := parseConfig(raw)
+    return err
+}
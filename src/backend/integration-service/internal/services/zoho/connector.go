@@ -0,0 +1,133 @@
+package zoho
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "log/slog"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/auth"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/connectors"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/heartbeat"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/webhooks"
+)
+
+// expectedCheckinInterval is the longest a healthy Zoho CRM integration is
+// expected to go between heartbeat checkins, which ZohoClient records after
+// each successful GetRecords call.
+const expectedCheckinInterval = 45 * time.Minute
+
+// webhookProvider is the {provider} path segment Zoho's inbound webhook
+// deliveries arrive under; see pkg/webhooks.Receiver.
+const webhookProvider = "zoho"
+
+func init() {
+    models.RegisterServiceType(models.ServiceTypeZohoCRM, validateConfig)
+    connectors.Register(models.ServiceTypeZohoCRM, NewConnector)
+    heartbeat.RegisterInterval(models.ServiceTypeZohoCRM, expectedCheckinInterval)
+    webhooks.RegisterVerifier(webhookProvider, webhooks.HMACSHA256Verifier("X-Zoho-Webhook-Signature"))
+}
+
+// zohoConnector adapts ZohoClient to the connectors.Connector interface.
+type zohoConnector struct {
+    client *ZohoClient
+}
+
+// discardLogger is the logger NewConnector hands ZohoClient, since
+// connectors.Factory's signature does not carry one; callers that need
+// the connector's own log output should construct ZohoClient directly.
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// NewConnector constructs a Zoho CRM connectors.Connector, satisfying
+// connectors.Factory.
+func NewConnector(integration *models.Integration, authManager *auth.OAuthManager, db *database.AtomicDB, metrics *prometheus.CounterVec) (connectors.Connector, error) {
+    if integration == nil {
+        return nil, fmt.Errorf("integration is required")
+    }
+    authenticator := auth.NewOAuthAuthenticator(authManager, integration.ID)
+    client := NewZohoClient(authenticator, integration.ID, db, metrics, discardLogger, reliabilityConfig(authManager.Config().ZohoCRMConfig))
+    return &zohoConnector{client: client}, nil
+}
+
+// Name implements connectors.Connector.
+func (c *zohoConnector) Name() string {
+    return models.ServiceTypeZohoCRM
+}
+
+// ConfigSchema implements connectors.Connector.
+func (c *zohoConnector) ConfigSchema() models.IntegrationConfig {
+    return Config{}
+}
+
+// HealthCheck implements connectors.Connector.
+func (c *zohoConnector) HealthCheck(ctx context.Context) error {
+    return c.client.HealthCheck(ctx)
+}
+
+// Sync implements connectors.Connector by pulling the Leads module, which
+// also has the side effect of confirming the integration is live.
+func (c *zohoConnector) Sync(ctx context.Context) error {
+    _, err := c.client.GetRecords(ctx, "Leads", nil)
+    return err
+}
+
+// Invoke implements connectors.Connector, dispatching to the handful of
+// actions the Zoho client exposes.
+func (c *zohoConnector) Invoke(ctx context.Context, action string, params map[string]interface{}) (interface{}, error) {
+    switch action {
+    case "get_records":
+        module, _ := params["module"].(string)
+        if module == "" {
+            return nil, fmt.Errorf("zoho connector: get_records requires a module param")
+        }
+        return c.client.GetRecords(ctx, module, params)
+    default:
+        return nil, fmt.Errorf("zoho connector: unsupported action: %s", action)
+    }
+}
+
+// defaultPageSize bounds how many records ListRecords asks Zoho CRM for in
+// a single page when the caller does not specify one.
+const defaultPageSize = 200
+
+// ListRecords implements connectors.Reader, paging through module via
+// Zoho CRM's page-number pagination.
+func (c *zohoConnector) ListRecords(ctx context.Context, module string, opts connectors.ListOptions) (connectors.ListResult, error) {
+    limit := opts.Limit
+    if limit <= 0 {
+        limit = defaultPageSize
+    }
+    page := 1
+    if opts.Cursor != "" {
+        if _, err := fmt.Sscanf(opts.Cursor, "%d", &page); err != nil {
+            return connectors.ListResult{}, fmt.Errorf("zoho connector: invalid cursor %q: %w", opts.Cursor, err)
+        }
+    }
+
+    filters := map[string]interface{}{"page": page, "per_page": limit}
+    if !opts.Since.IsZero() {
+        filters["modified_since"] = opts.Since.Format(time.RFC3339)
+    }
+
+    records, err := c.client.GetRecords(ctx, module, filters)
+    if err != nil {
+        return connectors.ListResult{}, err
+    }
+
+    done := len(records) < limit
+    result := connectors.ListResult{Records: records, Done: done}
+    if !done {
+        result.NextCursor = fmt.Sprintf("%d", page+1)
+    }
+    return result, nil
+}
+
+// CreateRecord implements connectors.Writer.
+func (c *zohoConnector) CreateRecord(ctx context.Context, module string, record map[string]interface{}) (map[string]interface{}, error) {
+    return c.client.CreateRecord(ctx, module, record)
+}
@@ -4,21 +4,39 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
-	"github.com/gofiber/fiber/v2"                 // v2.47.0
-	"github.com/gofiber/fiber/v2/middleware/cors" // v2.47.0
+	"github.com/gofiber/fiber/v2"                     // v2.47.0
+	"github.com/gofiber/fiber/v2/middleware/adaptor"  // v2.47.0
+	"github.com/gofiber/fiber/v2/middleware/cors"     // v2.47.0
 	"github.com/gofiber/fiber/v2/middleware/compress" // v2.47.0
-	"github.com/prometheus/client_golang/prometheus" // v1.16.0
-	"go.uber.org/zap"                              // v1.24.0
+	"github.com/prometheus/client_golang/prometheus"  // v1.16.0
+	"gorm.io/gorm"                                    // v1.25.0
 
 	"github.com/yourdomain/agent-ai-platform/integration-service/config"
+	"github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
+	"github.com/yourdomain/agent-ai-platform/integration-service/pkg/adminserver"
+	"github.com/yourdomain/agent-ai-platform/integration-service/pkg/auth"
+	"github.com/yourdomain/agent-ai-platform/integration-service/pkg/connectors"
 	"github.com/yourdomain/agent-ai-platform/integration-service/pkg/database"
+	"github.com/yourdomain/agent-ai-platform/integration-service/pkg/graphql"
+	"github.com/yourdomain/agent-ai-platform/integration-service/pkg/logging"
+	"github.com/yourdomain/agent-ai-platform/integration-service/pkg/webhooks"
+
+	// Connector packages register themselves via init(), the same way
+	// cmd/agentx-sync imports them for their side effects, so
+	// pkg/graphql's records resolver has a connector to build.
+	_ "github.com/yourdomain/agent-ai-platform/integration-service/internal/services/rms"
+	_ "github.com/yourdomain/agent-ai-platform/integration-service/internal/services/zoho"
 )
 
 const (
@@ -27,6 +45,18 @@ const (
 	shutdownTimeout  = 15 * time.Second
 	readTimeout     = 10 * time.Second
 	writeTimeout    = 10 * time.Second
+	logDedupWindow  = 1 * time.Second
+
+	// readinessTimeout bounds how long a single /readyz check (a database
+	// ping, a Redis ping, or one provider's HealthCheck) may take before
+	// it counts as a failure.
+	readinessTimeout = 5 * time.Second
+
+	// webhookQueueCapacity is how many inbound events the webhook
+	// receiver's in-memory ChannelQueue buffers before Push starts
+	// blocking. A production deployment is expected to supply a durable
+	// webhooks.Queue instead; see pkg/webhooks's package doc.
+	webhookQueueCapacity = 1000
 )
 
 func main() {
@@ -39,55 +69,141 @@ func main() {
 	defer cancel()
 
 	// Initialize logger
-	logger, err := initLogger()
-	if err != nil {
-		fmt.Printf("Failed to initialize logger: %v\n", err)
-		os.Exit(1)
-	}
-	defer logger.Sync()
+	logger := initLogger()
 
 	// Load configuration
 	cfg, err := config.LoadConfig(*configPath)
 	if err != nil {
-		logger.Fatal("Failed to load configuration", zap.Error(err))
+		fatal(logger, "Failed to load configuration", err)
 	}
 
-	// Initialize database connection
-	db, err := database.NewPostgresDB(&cfg.DatabaseConfig)
+	// Initialize database connection. credMgr keeps its AtomicDB pointed at
+	// a live connection pool, renewing dynamic credentials (see
+	// cfg.DatabaseConfig.CredentialSource) before their lease expires; for
+	// the default "static" source, Run just idles until shutdown.
+	credMgr, err := database.NewCredentialManager(&cfg.DatabaseConfig)
 	if err != nil {
-		logger.Fatal("Failed to connect to database", zap.Error(err))
+		fatal(logger, "Failed to connect to database", err)
 	}
-	defer database.Close(db)
+	go credMgr.Run(ctx)
+	defer database.Close(credMgr.DB().Get())
 
 	// Initialize metrics
 	if err := setupMetrics(); err != nil {
-		logger.Fatal("Failed to setup metrics", zap.Error(err))
+		fatal(logger, "Failed to setup metrics", err)
+	}
+
+	// authManager backs both outbound connector calls (via pkg/graphql's
+	// records resolver) and the bearer-token check on Integration.config.
+	authManager, err := auth.NewOAuthManager(cfg, credMgr.DB(), nil, logger)
+	if err != nil {
+		fatal(logger, "Failed to initialize OAuth manager", err)
+	}
+
+	connectorMetrics := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "integration_service_graphql_connector_operations_total",
+		Help: "Number of connector operations performed by GraphQL field resolvers, by outcome.",
+	}, []string{"outcome"})
+	if err := prometheus.Register(connectorMetrics); err != nil {
+		if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+			fatal(logger, "Failed to register connector metrics", err)
+		}
 	}
 
+	graphqlHandlers, err := graphql.NewHandlers(credMgr.DB(), authManager, connectorMetrics)
+	if err != nil {
+		fatal(logger, "Failed to initialize GraphQL handlers", err)
+	}
+
+	webhookMetrics := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "integration_service_webhook_receiver_operations_total",
+		Help: "Number of inbound webhook deliveries handled, by event and outcome.",
+	}, []string{"event", "outcome"})
+	if err := prometheus.Register(webhookMetrics); err != nil {
+		if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+			fatal(logger, "Failed to register webhook metrics", err)
+		}
+	}
+
+	// The Receiver pushes every first-seen, verified event onto its queue
+	// before dispatching it, so this fallback has nothing left to do; no
+	// connector registers a per-integration webhooks.Handler today, so it
+	// is the only handler events reach.
+	webhookDispatch := webhooks.NewDispatcher(func(webhooks.Context, webhooks.Event) error {
+		return nil
+	})
+	webhookReceiver := webhooks.NewReceiver(
+		credMgr.DB().Get(),
+		webhooks.NewGormDeliveryStore(credMgr.DB().Get()),
+		webhooks.NewChannelQueue(webhookQueueCapacity),
+		webhookDispatch,
+		webhookMetrics,
+	)
+
 	// Setup HTTP server
-	app := setupServer(cfg)
+	app := setupServer(cfg, graphqlHandlers, webhookReceiver, logger)
 
 	// Start server in a goroutine
 	go func() {
 		addr := fmt.Sprintf("%s:%d", cfg.ServerConfig.Host, cfg.ServerConfig.Port)
 		if err := app.Listen(addr); err != nil {
-			logger.Error("Server error", zap.Error(err))
+			logger.Error("Server error", "error", err)
+			cancel()
+		}
+	}()
+
+	// The integrationStatusChanged subscription transport needs a raw
+	// http.Hijacker for its websocket upgrade, which fasthttp (what fiber
+	// wraps) does not implement, so it runs on its own net/http server
+	// rather than being mounted into app. A zero port disables it.
+	var subServer *http.Server
+	if cfg.ServerConfig.GraphQLSubscriptionPort != 0 {
+		subServer = &http.Server{
+			Addr:    fmt.Sprintf("%s:%d", cfg.ServerConfig.Host, cfg.ServerConfig.GraphQLSubscriptionPort),
+			Handler: graphqlHandlers.Subscriptions,
+		}
+		go func() {
+			if err := subServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error("GraphQL subscription server error", "error", err)
+				cancel()
+			}
+		}()
+	}
+
+	// The admin listener (metrics, pprof, health/readiness) is kept off
+	// app's address entirely, rather than gated behind middleware on the
+	// same port, so a misconfigured CORS/auth rule on business traffic can
+	// never accidentally expose it.
+	adminSrv, err := setupAdminServer(cfg, authManager, credMgr.DB(), connectorMetrics, logger)
+	if err != nil {
+		fatal(logger, "Failed to initialize admin server", err)
+	}
+	go func() {
+		if err := adminSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error("Admin server error", "error", err)
 			cancel()
 		}
 	}()
 
 	// Wait for shutdown signal
-	if err := gracefulShutdown(ctx, app, db, logger); err != nil {
-		logger.Error("Error during shutdown", zap.Error(err))
+	if err := gracefulShutdown(ctx, app, subServer, adminSrv, credMgr.DB(), logger); err != nil {
+		logger.Error("Error during shutdown", "error", err)
 		os.Exit(1)
 	}
 
 	logger.Info("Service shutdown completed")
 }
 
+// fatal logs msg and err at error level and exits the process, standing
+// in for zap.Logger.Fatal now that slog has no equivalent method.
+func fatal(logger *slog.Logger, msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
+}
+
 // setupServer configures and initializes the HTTP server with security
 // and performance optimizations
-func setupServer(cfg *config.Config) *fiber.App {
+func setupServer(cfg *config.Config, graphqlHandlers *graphql.Handlers, webhookReceiver *webhooks.Receiver, logger *slog.Logger) *fiber.App {
 	app := fiber.New(fiber.Config{
 		ReadTimeout:  readTimeout,
 		WriteTimeout: writeTimeout,
@@ -95,6 +211,11 @@ func setupServer(cfg *config.Config) *fiber.App {
 		BodyLimit:    10 * 1024 * 1024, // 10MB
 	})
 
+	// Correlation ID middleware: every request gets a request-scoped
+	// logger (see logging.FromContext) tagged with a fresh request ID, so
+	// log lines from the same request can be grepped together.
+	app.Use(logging.RequestID(logger))
+
 	// Security middleware
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     cfg.SecurityConfig.AllowedOrigins,
@@ -115,18 +236,82 @@ func setupServer(cfg *config.Config) *fiber.App {
 		return c.SendString("OK")
 	})
 
-	// Metrics endpoint
-	app.Get("/metrics", func(c *fiber.Ctx) error {
-		metrics, err := prometheus.DefaultGatherer.Gather()
-		if err != nil {
-			return c.Status(500).SendString("Failed to gather metrics")
-		}
-		return c.JSON(metrics)
-	})
+	// GraphQL endpoint (queries and mutations only; see
+	// graphql.Handlers.Subscriptions for the websocket transport).
+	app.All("/graphql", adaptor.HTTPHandler(graphqlHandlers.Query))
+
+	// Inbound webhook deliveries; see pkg/webhooks.Receiver.
+	app.Post("/webhooks/:provider/:integrationID", adaptor.HTTPHandler(webhookReceiver))
 
 	return app
 }
 
+// setupAdminServer builds the admin/metrics listener (see
+// pkg/adminserver), wiring readiness checks for the database, Redis (via
+// authManager's cache), and every registered connector type.
+func setupAdminServer(cfg *config.Config, authManager *auth.OAuthManager, db *database.AtomicDB, connectorMetrics *prometheus.CounterVec, logger *slog.Logger) (*http.Server, error) {
+	allowedCIDRs, err := adminserver.ParseCIDRs(cfg.ServerConfig.Admin.AllowedCIDRs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse admin allowed_cidrs: %w", err)
+	}
+
+	checks := []adminserver.Check{
+		{Name: "database", Run: func(ctx context.Context) error {
+			sqlDB, err := db.Get().DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		}},
+		{Name: "redis", Run: func(ctx context.Context) error {
+			return authManager.Cache().Ping(ctx).Err()
+		}},
+	}
+	for _, serviceType := range connectors.RegisteredTypes() {
+		serviceType := serviceType
+		checks = append(checks, adminserver.Check{
+			Name: "provider:" + serviceType,
+			Run: func(ctx context.Context) error {
+				return checkProviderReachable(ctx, db, authManager, connectorMetrics, serviceType)
+			},
+		})
+	}
+
+	addr := fmt.Sprintf("%s:%d", cfg.ServerConfig.Admin.Host, cfg.ServerConfig.Admin.Port)
+	return adminserver.NewServer(addr, adminserver.Config{
+		AllowedCIDRs: allowedCIDRs,
+		BearerToken:  cfg.ServerConfig.Admin.BearerToken,
+	}, checks, logger), nil
+}
+
+// checkProviderReachable runs HealthCheck against the most recently
+// created active integration of serviceType; unlike cmd/agentx-sync's
+// findIntegration, it skips integrations in any other status. A service type with no
+// active integration yet is treated as healthy — there is nothing to be
+// unready for.
+func checkProviderReachable(ctx context.Context, db *database.AtomicDB, authManager *auth.OAuthManager, connectorMetrics *prometheus.CounterVec, serviceType string) error {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	var integration models.Integration
+	err := db.Get().WithContext(ctx).
+		Where("service_type = ? AND status = ?", serviceType, models.StatusActive).
+		Order("created_at DESC").
+		First(&integration).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to query %s integration: %w", serviceType, err)
+	}
+
+	connector, err := connectors.New(&integration, authManager, db, connectorMetrics)
+	if err != nil {
+		return fmt.Errorf("failed to build %s connector: %w", serviceType, err)
+	}
+	return connector.HealthCheck(ctx)
+}
+
 // setupMetrics initializes Prometheus metrics collectors
 func setupMetrics() error {
 	// Request duration histogram
@@ -161,17 +346,20 @@ func setupMetrics() error {
 	return nil
 }
 
-// initLogger initializes the structured logger
-func initLogger() (*zap.Logger, error) {
-	config := zap.NewProductionConfig()
-	config.OutputPaths = []string{"stdout"}
-	config.ErrorOutputPaths = []string{"stderr"}
-	
-	return config.Build()
+// initLogger initializes the structured logger, wrapping its JSON handler
+// in a logging.DedupHandler so a retry storm logging the same record
+// repeatedly collapses into one line plus a trailing count.
+func initLogger() *slog.Logger {
+	handler := slog.NewJSONHandler(os.Stdout, nil)
+	return slog.New(logging.NewDedupHandler(handler, logDedupWindow))
 }
 
-// gracefulShutdown manages graceful shutdown of service components
-func gracefulShutdown(ctx context.Context, app *fiber.App, db interface{}, logger *zap.Logger) error {
+// gracefulShutdown manages graceful shutdown of service components.
+// subServer is nil when the GraphQL subscription listener was never
+// started. app, subServer, and adminSrv are drained concurrently, since
+// none of their shutdowns depend on one another, before the database
+// connection is closed.
+func gracefulShutdown(ctx context.Context, app *fiber.App, subServer *http.Server, adminSrv *http.Server, db *database.AtomicDB, logger *slog.Logger) error {
 	// Setup shutdown signal handling
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT)
@@ -181,20 +369,53 @@ func gracefulShutdown(ctx context.Context, app *fiber.App, db interface{}, logge
 	case <-ctx.Done():
 		logger.Info("Shutdown initiated by context cancellation")
 	case sig := <-sigChan:
-		logger.Info("Shutdown initiated by signal", zap.String("signal", sig.String()))
+		logger.Info("Shutdown initiated by signal", "signal", sig.String())
 	}
 
 	// Create shutdown context with timeout
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
-	// Shutdown HTTP server
-	if err := app.ShutdownWithContext(shutdownCtx); err != nil {
-		return fmt.Errorf("error shutting down HTTP server: %w", err)
+	// Shut down the business, subscription, and admin listeners in
+	// parallel; each drains independently of the others.
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := app.ShutdownWithContext(shutdownCtx); err != nil {
+			errs[0] = fmt.Errorf("error shutting down HTTP server: %w", err)
+		}
+	}()
+
+	if subServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := subServer.Shutdown(shutdownCtx); err != nil {
+				errs[1] = fmt.Errorf("error shutting down GraphQL subscription server: %w", err)
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := adminSrv.Shutdown(shutdownCtx); err != nil {
+			errs[2] = fmt.Errorf("error shutting down admin server: %w", err)
+		}
+	}()
+
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
 	}
 
 	// Close database connection
-	if err := database.Close(db); err != nil {
+	if err := database.Close(db.Get()); err != nil {
 		return fmt.Errorf("error closing database connection: %w", err)
 	}
 
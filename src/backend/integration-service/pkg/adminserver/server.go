@@ -0,0 +1,148 @@
+// Package adminserver provides the operational HTTP listener that serves
+// metrics, pprof profiles, and liveness/readiness probes on a separate
+// address from the business-traffic server, so operational tooling never
+// shares a port (or its access controls) with public API traffic.
+package adminserver
+
+import (
+    "context"
+    "crypto/subtle"
+    "log/slog"
+    "net"
+    "net/http"
+    "net/http/pprof"
+    "strings"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Check is a single readiness probe. Name identifies it in a failing
+// /readyz response; Run reports the dependency's health, with ctx
+// carrying the request's deadline.
+type Check struct {
+    Name string
+    Run  func(ctx context.Context) error
+}
+
+// Config controls access to the admin listener built by NewServer.
+type Config struct {
+    // AllowedCIDRs restricts requests to the given source networks. A nil
+    // or empty slice allows any source, relying on BearerToken alone.
+    AllowedCIDRs []*net.IPNet
+    // BearerToken, when non-empty, is required as an "Authorization:
+    // Bearer <token>" header on every request.
+    BearerToken string
+}
+
+// NewServer builds the admin *http.Server bound to addr. It serves:
+//
+//   - /healthz     liveness: always 200 once the process is up
+//   - /readyz      readiness: runs checks in order, failing fast (503) on
+//     the first one that errors
+//   - /metrics     Prometheus exposition format via promhttp, replacing
+//     the ad hoc JSON /metrics setupServer used to serve on the business
+//     listener
+//   - /debug/pprof/*  Go's runtime profiler
+//
+// Every route is gated by cfg's CIDR allowlist and/or bearer token (see
+// gate), so this operationally sensitive surface is never exposed the way
+// GraphQL traffic on ServerConfig's listener is.
+func NewServer(addr string, cfg Config, checks []Check, logger *slog.Logger) *http.Server {
+    if len(cfg.AllowedCIDRs) == 0 && cfg.BearerToken == "" {
+        logger.Warn("Admin listener has no AllowedCIDRs or BearerToken configured; it is unauthenticated", "addr", addr)
+    }
+
+    mux := http.NewServeMux()
+
+    mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+        w.Write([]byte("OK"))
+    })
+
+    mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+        for _, check := range checks {
+            if err := check.Run(r.Context()); err != nil {
+                logger.Warn("Readiness check failed", "check", check.Name, "error", err)
+                http.Error(w, check.Name+": not ready: "+err.Error(), http.StatusServiceUnavailable)
+                return
+            }
+        }
+        w.Write([]byte("OK"))
+    })
+
+    mux.Handle("/metrics", promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{}))
+
+    mux.HandleFunc("/debug/pprof/", pprof.Index)
+    mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+    mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+    mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+    mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+    return &http.Server{
+        Addr:    addr,
+        Handler: gate(cfg, mux),
+    }
+}
+
+// gate wraps next with cfg's CIDR allowlist and bearer-token checks,
+// rejecting a request that fails any configured check; when both are set,
+// both must pass.
+func gate(cfg Config, next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if len(cfg.AllowedCIDRs) > 0 && !sourceAllowed(r.RemoteAddr, cfg.AllowedCIDRs) {
+            http.Error(w, "forbidden", http.StatusForbidden)
+            return
+        }
+        if cfg.BearerToken != "" && !validBearer(r, cfg.BearerToken) {
+            http.Error(w, "unauthorized", http.StatusUnauthorized)
+            return
+        }
+        next.ServeHTTP(w, r)
+    })
+}
+
+// sourceAllowed reports whether remoteAddr (an http.Request.RemoteAddr,
+// "host:port") falls within one of allowed.
+func sourceAllowed(remoteAddr string, allowed []*net.IPNet) bool {
+    host, _, err := net.SplitHostPort(remoteAddr)
+    if err != nil {
+        host = remoteAddr
+    }
+    ip := net.ParseIP(host)
+    if ip == nil {
+        return false
+    }
+    for _, cidr := range allowed {
+        if cidr.Contains(ip) {
+            return true
+        }
+    }
+    return false
+}
+
+// validBearer reports whether r carries an Authorization header matching
+// token, compared in constant time to avoid leaking the token's contents
+// through response-timing side channels.
+func validBearer(r *http.Request, token string) bool {
+    const prefix = "Bearer "
+    header := r.Header.Get("Authorization")
+    if !strings.HasPrefix(header, prefix) {
+        return false
+    }
+    supplied := strings.TrimPrefix(header, prefix)
+    return subtle.ConstantTimeCompare([]byte(supplied), []byte(token)) == 1
+}
+
+// ParseCIDRs parses each entry of cidrs (already validated by config's
+// "cidr" validator tag) into a *net.IPNet for Config.AllowedCIDRs.
+func ParseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+    nets := make([]*net.IPNet, 0, len(cidrs))
+    for _, raw := range cidrs {
+        _, ipNet, err := net.ParseCIDR(raw)
+        if err != nil {
+            return nil, err
+        }
+        nets = append(nets, ipNet)
+    }
+    return nets, nil
+}
@@ -0,0 +1,222 @@
+// Package audit provides an append-only, hash-chained audit log for
+// security-sensitive events across the integration service: configuration
+// loads and secret decryption (config.LoadConfig), database transaction
+// outcomes (database.WithTransaction), and outbound API calls
+// (zoho.ZohoClient.doRequest and friends). Each event's hash commits to the
+// previous event's hash, so tampering with or removing an entry breaks the
+// chain from that point forward; Verify (and cmd/agentx-audit's "verify"
+// subcommand) detect exactly that.
+package audit
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "strings"
+    "sync"
+    "time"
+)
+
+// Event is a single audit record. PrevHash and Hash form the hash chain:
+// Hash = sha256(PrevHash || json(event with Hash cleared)). Details should
+// hold fingerprints and metadata, never raw secrets or payloads; see
+// Fingerprint and RedactHeaders.
+type Event struct {
+    Sequence      uint64                 `json:"sequence"`
+    Timestamp     time.Time              `json:"timestamp"`
+    Actor         string                 `json:"actor"`
+    IntegrationID string                 `json:"integration_id,omitempty"`
+    Action        string                 `json:"action"`
+    Outcome       string                 `json:"outcome"`
+    Details       map[string]interface{} `json:"details,omitempty"`
+    PrevHash      string                 `json:"prev_hash"`
+    Hash          string                 `json:"event_hash"`
+}
+
+// Sink persists a single, already hash-chained Event to a destination
+// (file, syslog, webhook, ...).
+type Sink interface {
+    Write(event Event) error
+}
+
+// Logger hash-chains Events and fans them out to one or more Sinks. The
+// zero Logger (no sinks) makes Record a no-op, which is how the package's
+// default logger behaves before Init is called or when auditing is
+// disabled, so callers can hold a *Logger unconditionally.
+type Logger struct {
+    mu       sync.Mutex
+    sinks    []Sink
+    seq      uint64
+    prevHash string
+}
+
+// NewLogger builds a Logger writing every Record'd event to sinks, in
+// order.
+func NewLogger(sinks ...Sink) *Logger {
+    return &Logger{sinks: sinks}
+}
+
+// Record assigns event the next sequence number and PrevHash, computes its
+// Hash, and writes it to every sink. A nil Logger or one with no sinks
+// does nothing and returns nil. Sink errors are collected so that one
+// failing sink does not stop the others from receiving the event; the
+// chain itself always advances, since the hash only depends on the event's
+// own fields, not on whether any sink accepted it.
+func (l *Logger) Record(event Event) error {
+    if l == nil || len(l.sinks) == 0 {
+        return nil
+    }
+
+    l.mu.Lock()
+    event.Sequence = l.seq + 1
+    event.PrevHash = l.prevHash
+    if event.Timestamp.IsZero() {
+        event.Timestamp = time.Now()
+    }
+    hash, err := hashEvent(event)
+    if err != nil {
+        l.mu.Unlock()
+        return fmt.Errorf("audit: hash event: %w", err)
+    }
+    event.Hash = hash
+    l.seq = event.Sequence
+    l.prevHash = event.Hash
+    sinks := l.sinks
+    l.mu.Unlock()
+
+    var firstErr error
+    failed := 0
+    for _, sink := range sinks {
+        if err := sink.Write(event); err != nil {
+            failed++
+            if firstErr == nil {
+                firstErr = err
+            }
+        }
+    }
+    if firstErr != nil {
+        return fmt.Errorf("audit: %d of %d sink(s) failed: %w", failed, len(sinks), firstErr)
+    }
+    return nil
+}
+
+// hashEvent computes sha256(event.PrevHash || canonicalJSON(event)) with
+// Hash cleared, hex-encoded. encoding/json sorts map keys and serializes
+// struct fields in declaration order, which is deterministic enough for a
+// chain this package alone produces and verifies.
+func hashEvent(event Event) (string, error) {
+    event.Hash = ""
+    data, err := json.Marshal(event)
+    if err != nil {
+        return "", err
+    }
+    sum := sha256.Sum256(append([]byte(event.PrevHash), data...))
+    return hex.EncodeToString(sum[:]), nil
+}
+
+// Config selects which sinks Init builds and whether auditing runs at all.
+// It is deliberately independent of config.SecurityConfig (populated from
+// its Audit* fields by the caller) so pkg/audit has no import back to
+// config.
+type Config struct {
+    Enabled bool
+
+    // FilePath, if set, enables a FileSink rotated per FileMaxSizeMB and
+    // FileMaxAgeDays.
+    FilePath       string
+    FileMaxSizeMB  int
+    FileMaxAgeDays int
+
+    // SyslogNetwork/SyslogAddr, if either is set, enables a SyslogSink. An
+    // empty network dials the local syslog daemon.
+    SyslogNetwork string
+    SyslogAddr    string
+
+    // WebhookURL, if set, enables a WebhookSink POSTing every event as
+    // JSON. WebhookTimeout defaults to 10s when zero.
+    WebhookURL     string
+    WebhookTimeout time.Duration
+}
+
+var (
+    defaultMu     sync.RWMutex
+    defaultLogger = NewLogger()
+)
+
+// Init builds the process-wide default Logger used by Record from cfg,
+// replacing whatever Init previously installed. cfg.Enabled == false
+// leaves Record a no-op. It is called once from config.LoadConfig, as soon
+// as SecurityConfig is available.
+func Init(cfg Config) error {
+    if !cfg.Enabled {
+        defaultMu.Lock()
+        defaultLogger = NewLogger()
+        defaultMu.Unlock()
+        return nil
+    }
+
+    var sinks []Sink
+    if cfg.FilePath != "" {
+        sinks = append(sinks, NewFileSink(cfg.FilePath, cfg.FileMaxSizeMB, cfg.FileMaxAgeDays))
+    }
+    if cfg.SyslogAddr != "" || cfg.SyslogNetwork != "" {
+        sink, err := NewSyslogSink(cfg.SyslogNetwork, cfg.SyslogAddr)
+        if err != nil {
+            return fmt.Errorf("audit: %w", err)
+        }
+        sinks = append(sinks, sink)
+    }
+    if cfg.WebhookURL != "" {
+        sinks = append(sinks, NewWebhookSink(cfg.WebhookURL, cfg.WebhookTimeout))
+    }
+    if len(sinks) == 0 {
+        return fmt.Errorf("audit: enabled but no sink is configured (set audit_file_path, audit_syslog_addr, or audit_webhook_url)")
+    }
+
+    defaultMu.Lock()
+    defaultLogger = NewLogger(sinks...)
+    defaultMu.Unlock()
+    return nil
+}
+
+// Record appends event to the process-wide default Logger installed by
+// Init. It is a no-op until Init has been called with an enabled Config.
+func Record(event Event) error {
+    defaultMu.RLock()
+    logger := defaultLogger
+    defaultMu.RUnlock()
+    return logger.Record(event)
+}
+
+// Fingerprint returns a hex-encoded sha256 digest of data, so an audit
+// event can reference a request or response body's exact content without
+// the (potentially sensitive) body itself ever reaching the audit log.
+func Fingerprint(data []byte) string {
+    sum := sha256.Sum256(data)
+    return hex.EncodeToString(sum[:])
+}
+
+// sensitiveHeaders lists the header names RedactHeaders always scrubs.
+var sensitiveHeaders = map[string]bool{
+    "authorization": true,
+    "cookie":        true,
+    "set-cookie":    true,
+    "x-api-key":     true,
+}
+
+// RedactHeaders copies h into a map suitable for Event.Details, replacing
+// the value of any sensitive header (auth tokens, cookies, API keys) with
+// "REDACTED".
+func RedactHeaders(h http.Header) map[string]string {
+    redacted := make(map[string]string, len(h))
+    for key := range h {
+        if sensitiveHeaders[strings.ToLower(key)] {
+            redacted[key] = "REDACTED"
+            continue
+        }
+        redacted[key] = h.Get(key)
+    }
+    return redacted
+}
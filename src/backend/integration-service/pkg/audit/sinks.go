@@ -0,0 +1,125 @@
+package audit
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "log/syslog"
+    "net/http"
+    "sync"
+    "time"
+
+    "gopkg.in/natefinch/lumberjack.v2" // v2.2.1
+)
+
+const defaultWebhookTimeout = 10 * time.Second
+
+// FileSink appends each Event as a JSON line to a rotated log file.
+// Rotation itself is delegated to lumberjack, which rotates on MaxSizeMB
+// and prunes backups older than MaxAgeDays; FileSink only decides what
+// bytes to write and serializes concurrent writers.
+type FileSink struct {
+    mu     sync.Mutex
+    writer *lumberjack.Logger
+}
+
+// NewFileSink opens (creating if needed) the audit log at path. A zero
+// maxSizeMB/maxAgeDays falls back to lumberjack's own defaults (100MB,
+// unbounded age).
+func NewFileSink(path string, maxSizeMB, maxAgeDays int) *FileSink {
+    return &FileSink{
+        writer: &lumberjack.Logger{
+            Filename: path,
+            MaxSize:  maxSizeMB,
+            MaxAge:   maxAgeDays,
+            Compress: true,
+        },
+    }
+}
+
+// Write implements Sink.
+func (s *FileSink) Write(event Event) error {
+    data, err := json.Marshal(event)
+    if err != nil {
+        return fmt.Errorf("audit: marshal event for file sink: %w", err)
+    }
+    data = append(data, '\n')
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    _, err = s.writer.Write(data)
+    return err
+}
+
+// Close flushes and closes the underlying file.
+func (s *FileSink) Close() error {
+    return s.writer.Close()
+}
+
+// SyslogSink forwards each Event, JSON-encoded, to syslog at LOG_INFO
+// under the "integration-service-audit" tag.
+type SyslogSink struct {
+    writer *syslog.Writer
+}
+
+// NewSyslogSink dials network/addr ("", "" dials the local syslog daemon
+// over its default transport; "udp"/"tcp" and a "host:port" addr dial a
+// remote syslog collector).
+func NewSyslogSink(network, addr string) (*SyslogSink, error) {
+    w, err := syslog.Dial(network, addr, syslog.LOG_INFO|syslog.LOG_AUTH, "integration-service-audit")
+    if err != nil {
+        return nil, fmt.Errorf("dial syslog: %w", err)
+    }
+    return &SyslogSink{writer: w}, nil
+}
+
+// Write implements Sink.
+func (s *SyslogSink) Write(event Event) error {
+    data, err := json.Marshal(event)
+    if err != nil {
+        return fmt.Errorf("audit: marshal event for syslog sink: %w", err)
+    }
+    return s.writer.Info(string(data))
+}
+
+// Close closes the syslog connection.
+func (s *SyslogSink) Close() error {
+    return s.writer.Close()
+}
+
+// WebhookSink POSTs each Event as JSON to a configured URL. Delivery is
+// synchronous with Logger.Record: a failed POST is returned to the
+// Record caller as an error, but the event has already advanced the hash
+// chain, so operators should alert on sink errors rather than expect a
+// retry to preserve ordering.
+type WebhookSink struct {
+    url    string
+    client *http.Client
+}
+
+// NewWebhookSink builds a WebhookSink posting to url with a bounded
+// request timeout; timeout <= 0 defaults to 10s.
+func NewWebhookSink(url string, timeout time.Duration) *WebhookSink {
+    if timeout <= 0 {
+        timeout = defaultWebhookTimeout
+    }
+    return &WebhookSink{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+// Write implements Sink.
+func (s *WebhookSink) Write(event Event) error {
+    data, err := json.Marshal(event)
+    if err != nil {
+        return fmt.Errorf("audit: marshal event for webhook sink: %w", err)
+    }
+
+    resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(data))
+    if err != nil {
+        return fmt.Errorf("audit: webhook delivery: %w", err)
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode >= 300 {
+        return fmt.Errorf("audit: webhook returned status %d", resp.StatusCode)
+    }
+    return nil
+}
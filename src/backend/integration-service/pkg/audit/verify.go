@@ -0,0 +1,60 @@
+package audit
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "os"
+)
+
+// Verify checks that events form a valid, untampered hash chain: sequence
+// numbers must be contiguous starting at 1, each event's PrevHash must
+// equal the preceding event's Hash, and recomputing each event's Hash from
+// its own fields must reproduce the stored Hash. It returns the index of
+// the first event that fails any of those checks, or -1 if the whole
+// chain verifies.
+func Verify(events []Event) (int, error) {
+    prevHash := ""
+    for i, event := range events {
+        if event.Sequence != uint64(i+1) {
+            return i, fmt.Errorf("event %d: expected sequence %d, got %d", i, i+1, event.Sequence)
+        }
+        if event.PrevHash != prevHash {
+            return i, fmt.Errorf("event %d: prev_hash %q does not match preceding event's hash %q", i, event.PrevHash, prevHash)
+        }
+        wantHash := event.Hash
+        gotHash, err := hashEvent(event)
+        if err != nil {
+            return i, fmt.Errorf("event %d: %w", i, err)
+        }
+        if gotHash != wantHash {
+            return i, fmt.Errorf("event %d: event_hash %q does not match recomputed hash %q (tampered or reordered?)", i, wantHash, gotHash)
+        }
+        prevHash = wantHash
+    }
+    return -1, nil
+}
+
+// VerifyFile reads a FileSink's newline-delimited JSON log from path and
+// runs Verify over it.
+func VerifyFile(path string) (int, error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return -1, fmt.Errorf("audit: read %s: %w", path, err)
+    }
+
+    var events []Event
+    for _, line := range bytes.Split(data, []byte("\n")) {
+        line = bytes.TrimSpace(line)
+        if len(line) == 0 {
+            continue
+        }
+        var event Event
+        if err := json.Unmarshal(line, &event); err != nil {
+            return -1, fmt.Errorf("audit: parse line %d: %w", len(events)+1, err)
+        }
+        events = append(events, event)
+    }
+
+    return Verify(events)
+}
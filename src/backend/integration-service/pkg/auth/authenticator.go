@@ -0,0 +1,24 @@
+package auth
+
+import (
+    "context"
+    "net/http"
+)
+
+// Authenticator applies a single authentication scheme to an outgoing
+// request, mutating it in place (typically by setting one or more headers).
+// OAuthManager.GetToken remains the only token source, but callers no longer
+// need to reach into it directly: they depend on an Authenticator instead,
+// which may be an OAuthAuthenticator, one of the other schemes below, or a
+// Composed authenticator applying several at once.
+type Authenticator interface {
+    Apply(ctx context.Context, req *http.Request) error
+}
+
+// AuthenticatorFunc adapts a plain function to the Authenticator interface.
+type AuthenticatorFunc func(ctx context.Context, req *http.Request) error
+
+// Apply implements Authenticator.
+func (f AuthenticatorFunc) Apply(ctx context.Context, req *http.Request) error {
+    return f(ctx, req)
+}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+)
+
+// Composed applies a sequence of Authenticators to the same request, in
+// order, stopping at the first error. This covers integrations that layer
+// schemes — e.g. an API key gate in front of an OAuth bearer token — rather
+// than using exactly one.
+type Composed struct {
+    authenticators []Authenticator
+}
+
+// NewComposed creates a Composed authenticator applying each of
+// authenticators in order. It is itself an Authenticator, so composed
+// authenticators can be nested.
+func NewComposed(authenticators ...Authenticator) *Composed {
+    return &Composed{authenticators: authenticators}
+}
+
+// Apply implements Authenticator.
+func (c *Composed) Apply(ctx context.Context, req *http.Request) error {
+    for i, a := range c.authenticators {
+        if err := a.Apply(ctx, req); err != nil {
+            return fmt.Errorf("composed authenticator: step %d: %w", i, err)
+        }
+    }
+    return nil
+}
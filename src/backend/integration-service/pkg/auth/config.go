@@ -0,0 +1,156 @@
+package auth
+
+import (
+    "fmt"
+
+    "github.com/google/uuid"
+)
+
+// Scheme names recognized by SchemeConfig.Scheme.
+const (
+    SchemeOAuth2   = "oauth2"
+    SchemeAPIKey   = "apikey"
+    SchemeHMAC     = "hmac"
+    SchemeBasic    = "basic"
+    SchemeMTLS     = "mtls"
+    SchemeComposed = "composed"
+)
+
+// SchemeConfig describes the authentication scheme an integration uses, as
+// persisted under the "auth" key of Integration.Config. Connector packages
+// embed it in their own Config type, call Validate from their own
+// validateConfig, and call Build once they hold an *OAuthManager (needed
+// only for SchemeOAuth2) to obtain the Authenticator to use at request time.
+//
+// An empty SchemeConfig (Scheme == "") defaults to SchemeOAuth2, matching
+// integrations created before pluggable schemes existed.
+type SchemeConfig struct {
+    Scheme string `json:"scheme,omitempty"`
+
+    APIKey *APIKeyConfig `json:"api_key,omitempty"`
+    HMAC   *HMACConfig   `json:"hmac,omitempty"`
+    Basic  *BasicConfig  `json:"basic,omitempty"`
+    MTLS   *MTLSConfig   `json:"mtls,omitempty"`
+
+    // Compose holds the schemes to apply in order when Scheme == SchemeComposed.
+    Compose []SchemeConfig `json:"compose,omitempty"`
+}
+
+// APIKeyConfig holds the fields SchemeAPIKey needs.
+type APIKeyConfig struct {
+    Header string `json:"header,omitempty"`
+    Key    string `json:"key"`
+}
+
+// HMACConfig holds the fields SchemeHMAC needs.
+type HMACConfig struct {
+    KeyID  string `json:"key_id,omitempty"`
+    Secret string `json:"secret"`
+}
+
+// BasicConfig holds the fields SchemeBasic needs.
+type BasicConfig struct {
+    Username string `json:"username"`
+    Password string `json:"password,omitempty"`
+}
+
+// MTLSConfig holds the fields SchemeMTLS needs. CertFile/KeyFile/CAFile are
+// paths rather than inline PEM so certificate material isn't duplicated
+// into Integration.Config.
+type MTLSConfig struct {
+    CertFile string `json:"cert_file"`
+    KeyFile  string `json:"key_file"`
+    CAFile   string `json:"ca_file,omitempty"`
+}
+
+// Validate checks that the selected scheme has the config block it needs.
+// It does not reach out to the filesystem or network; NewMTLSTransport does
+// that lazily when the connection is actually built.
+func (c SchemeConfig) Validate() error {
+    switch c.scheme() {
+    case SchemeOAuth2:
+        return nil
+    case SchemeAPIKey:
+        if c.APIKey == nil || c.APIKey.Key == "" {
+            return fmt.Errorf("auth: scheme %q requires api_key.key", SchemeAPIKey)
+        }
+    case SchemeHMAC:
+        if c.HMAC == nil || c.HMAC.Secret == "" {
+            return fmt.Errorf("auth: scheme %q requires hmac.secret", SchemeHMAC)
+        }
+    case SchemeBasic:
+        if c.Basic == nil || c.Basic.Username == "" {
+            return fmt.Errorf("auth: scheme %q requires basic.username", SchemeBasic)
+        }
+    case SchemeMTLS:
+        if c.MTLS == nil || c.MTLS.CertFile == "" || c.MTLS.KeyFile == "" {
+            return fmt.Errorf("auth: scheme %q requires mtls.cert_file and mtls.key_file", SchemeMTLS)
+        }
+    case SchemeComposed:
+        if len(c.Compose) == 0 {
+            return fmt.Errorf("auth: scheme %q requires a non-empty compose list", SchemeComposed)
+        }
+        for i, sub := range c.Compose {
+            if sub.scheme() == SchemeComposed {
+                return fmt.Errorf("auth: compose[%d]: nested composed schemes are not supported", i)
+            }
+            if err := sub.Validate(); err != nil {
+                return fmt.Errorf("auth: compose[%d]: %w", i, err)
+            }
+        }
+    default:
+        return fmt.Errorf("auth: unsupported scheme: %s", c.Scheme)
+    }
+    return nil
+}
+
+// Build turns c into the Authenticator it describes. manager and
+// integrationID are only consulted for SchemeOAuth2 (including inside a
+// composed scheme); other schemes construct directly from c.
+func (c SchemeConfig) Build(manager *OAuthManager, integrationID uuid.UUID) (Authenticator, error) {
+    switch c.scheme() {
+    case SchemeOAuth2:
+        if manager == nil {
+            return nil, fmt.Errorf("auth: scheme %q requires an OAuthManager", SchemeOAuth2)
+        }
+        return NewOAuthAuthenticator(manager, integrationID), nil
+    case SchemeAPIKey:
+        if c.APIKey == nil {
+            return nil, fmt.Errorf("auth: scheme %q is missing api_key config", SchemeAPIKey)
+        }
+        return NewAPIKeyAuthenticator(c.APIKey.Header, c.APIKey.Key), nil
+    case SchemeHMAC:
+        if c.HMAC == nil {
+            return nil, fmt.Errorf("auth: scheme %q is missing hmac config", SchemeHMAC)
+        }
+        return NewHMACAuthenticator(c.HMAC.KeyID, c.HMAC.Secret), nil
+    case SchemeBasic:
+        if c.Basic == nil {
+            return nil, fmt.Errorf("auth: scheme %q is missing basic config", SchemeBasic)
+        }
+        return NewBasicAuthenticator(c.Basic.Username, c.Basic.Password), nil
+    case SchemeMTLS:
+        return NewMTLSAuthenticator(), nil
+    case SchemeComposed:
+        authenticators := make([]Authenticator, 0, len(c.Compose))
+        for i, sub := range c.Compose {
+            a, err := sub.Build(manager, integrationID)
+            if err != nil {
+                return nil, fmt.Errorf("auth: compose[%d]: %w", i, err)
+            }
+            authenticators = append(authenticators, a)
+        }
+        return NewComposed(authenticators...), nil
+    default:
+        return nil, fmt.Errorf("auth: unsupported scheme: %s", c.Scheme)
+    }
+}
+
+// scheme returns c.Scheme, defaulting to SchemeOAuth2 for the zero value so
+// integrations persisted before schemes existed keep working unchanged.
+func (c SchemeConfig) scheme() string {
+    if c.Scheme == "" {
+        return SchemeOAuth2
+    }
+    return c.Scheme
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "net/url"
+    "strings"
+    "time"
+)
+
+// DeviceAuthorization holds the values an RFC 8628 device-authorization
+// request returns: the codes and URLs a user needs to complete sign-in on
+// a second device, and how often/long the HTTP layer should poll the
+// token endpoint with DeviceCode before ExpiresIn elapses.
+type DeviceAuthorization struct {
+    DeviceCode              string
+    UserCode                string
+    VerificationURI         string
+    VerificationURIComplete string
+    ExpiresIn               time.Duration
+    Interval                time.Duration
+}
+
+// deviceAuthorizationResponse mirrors RFC 8628 section 3.2's JSON response.
+type deviceAuthorizationResponse struct {
+    DeviceCode              string `json:"device_code"`
+    UserCode                string `json:"user_code"`
+    VerificationURI         string `json:"verification_uri"`
+    VerificationURIComplete string `json:"verification_uri_complete"`
+    ExpiresIn               int    `json:"expires_in"`
+    Interval                int    `json:"interval"`
+}
+
+// requestDeviceAuthorization performs the RFC 8628 section 3.1 request
+// against cfg.DeviceAuthURL.
+func requestDeviceAuthorization(ctx context.Context, cfg ProviderConfig) (*DeviceAuthorization, error) {
+    form := url.Values{"client_id": {cfg.ClientID}}
+    if len(cfg.Scopes) > 0 {
+        form.Set("scope", strings.Join(cfg.Scopes, " "))
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.DeviceAuthURL, strings.NewReader(form.Encode()))
+    if err != nil {
+        return nil, fmt.Errorf("auth: building device authorization request: %w", err)
+    }
+    req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+    req.Header.Set("Accept", "application/json")
+
+    resp, err := http.DefaultClient.Do(req)
+    if err != nil {
+        return nil, fmt.Errorf("auth: device authorization request: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("auth: device authorization request returned %s", resp.Status)
+    }
+
+    var body deviceAuthorizationResponse
+    if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+        return nil, fmt.Errorf("auth: decoding device authorization response: %w", err)
+    }
+
+    interval := time.Duration(body.Interval) * time.Second
+    if interval == 0 {
+        interval = 5 * time.Second
+    }
+
+    return &DeviceAuthorization{
+        DeviceCode:              body.DeviceCode,
+        UserCode:                body.UserCode,
+        VerificationURI:         body.VerificationURI,
+        VerificationURIComplete: body.VerificationURIComplete,
+        ExpiresIn:               time.Duration(body.ExpiresIn) * time.Second,
+        Interval:                interval,
+    }, nil
+}
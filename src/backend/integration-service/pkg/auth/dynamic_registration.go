@@ -0,0 +1,68 @@
+package auth
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "strings"
+)
+
+// dynamicClientRequest mirrors the subset of RFC 7591 section 2's client
+// metadata OAuthManager has enough information to supply.
+type dynamicClientRequest struct {
+    ClientName   string   `json:"client_name"`
+    RedirectURIs []string `json:"redirect_uris,omitempty"`
+    GrantTypes   []string `json:"grant_types"`
+    Scope        string   `json:"scope,omitempty"`
+}
+
+// dynamicClientResponse mirrors the subset of RFC 7591 section 3.2.1's
+// response OAuthManager needs to start using the new client.
+type dynamicClientResponse struct {
+    ClientID     string `json:"client_id"`
+    ClientSecret string `json:"client_secret"`
+}
+
+// registerDynamicClient performs RFC 7591 dynamic client registration
+// against cfg.RegistrationURL, returning the client_id/client_secret the
+// authorization server issued.
+func registerDynamicClient(ctx context.Context, cfg ProviderConfig) (clientID, clientSecret string, err error) {
+    body, err := json.Marshal(dynamicClientRequest{
+        ClientName:   fmt.Sprintf("integration-service (%s)", cfg.Name),
+        RedirectURIs: []string{cfg.RedirectURL},
+        GrantTypes:   []string{"authorization_code", "refresh_token"},
+        Scope:        strings.Join(cfg.Scopes, " "),
+    })
+    if err != nil {
+        return "", "", fmt.Errorf("auth: marshaling registration request: %w", err)
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.RegistrationURL, bytes.NewReader(body))
+    if err != nil {
+        return "", "", fmt.Errorf("auth: building registration request: %w", err)
+    }
+    req.Header.Set("Content-Type", "application/json")
+    req.Header.Set("Accept", "application/json")
+
+    resp, err := http.DefaultClient.Do(req)
+    if err != nil {
+        return "", "", fmt.Errorf("auth: registration request: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+        return "", "", fmt.Errorf("auth: registration request returned %s", resp.Status)
+    }
+
+    var respBody dynamicClientResponse
+    if err := json.NewDecoder(resp.Body).Decode(&respBody); err != nil {
+        return "", "", fmt.Errorf("auth: decoding registration response: %w", err)
+    }
+    if respBody.ClientID == "" {
+        return "", "", fmt.Errorf("auth: registration response missing client_id")
+    }
+
+    return respBody.ClientID, respBody.ClientSecret, nil
+}
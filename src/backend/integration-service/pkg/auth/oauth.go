@@ -4,12 +4,11 @@ package auth
 
 import (
     "context"
-    "crypto/aes"
-    "crypto/cipher"
-    "encoding/base64"
-    "encoding/json"
     "errors"
     "fmt"
+    "log/slog"
+    "math/rand"
+    "strings"
     "sync"
     "time"
 
@@ -21,6 +20,8 @@ import (
     "github.com/yourdomain/agent-ai-platform/integration-service/config"
     "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
     "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/logging"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/secrets"
 )
 
 const (
@@ -30,35 +31,59 @@ const (
     maxRequestsPerWindow = 100
     cacheKeyPrefix      = "oauth_token:"
     cacheExpiration     = 30 * time.Minute
+
+    // refreshBackoffBase is the unit refreshToken's retry loop backs off
+    // by: attempt N waits 2^(N-1) * refreshBackoffBase, plus up to
+    // refreshBackoffBase of jitter, unless a 429's Retry-After overrides it.
+    refreshBackoffBase = 200 * time.Millisecond
 )
 
 var (
     encryptionKey []byte
     metrics       *oauthMetrics
+
+    // ErrCrossTenantAccess is returned by GetTokenForTenant when the
+    // integration it was asked for belongs to a different tenant than the
+    // one claimed, so callers (GraphQL resolvers, webhook handlers) can
+    // distinguish a cross-tenant access attempt from an ordinary
+    // not-found or refresh failure.
+    ErrCrossTenantAccess = errors.New("auth: integration belongs to a different tenant")
 )
 
 // OAuthManager handles OAuth authentication with enhanced security and monitoring
 type OAuthManager struct {
-    config       *config.Config
-    db           *database.PostgresDB
-    cache        *redis.Client
-    logger       *log.Logger
-    oauthClients map[string]*oauth2.Config
-    rateLimiter  *rateLimiter
-    metrics      *oauthMetrics
-    mu           sync.RWMutex
+    config      *config.Config
+    db          *database.AtomicDB
+    cache       *redis.Client
+    logger      *slog.Logger
+    registry    *ProviderRegistry
+    tokenCrypto *TokenCrypto
+    rateLimiter *rateLimiter
+    metrics     *oauthMetrics
+    mu          sync.RWMutex
+
+    // tenantCrypto lazily caches one *TokenCrypto per tenant, each sealing
+    // tokens under its own DEK (see tokenCryptoFor), so a tenant's key
+    // provider is only resolved once rather than on every GetToken call.
+    tenantCrypto   map[string]*TokenCrypto
+    tenantCryptoMu sync.RWMutex
 }
 
-// oauthMetrics tracks OAuth-related metrics
+// oauthMetrics tracks OAuth-related metrics, labeled by tenant and provider
+// so operators can see per-tenant traffic and SLOs rather than one global
+// rate.
 type oauthMetrics struct {
-    tokenRequests    prometheus.Counter
-    tokenRefreshes   prometheus.Counter
-    errors          prometheus.Counter
-    responseTime    prometheus.Histogram
+    tokenRequests  *prometheus.CounterVec
+    tokenRefreshes *prometheus.CounterVec
+    errors         *prometheus.CounterVec
+    responseTime   *prometheus.HistogramVec
 }
 
-// NewOAuthManager creates a new OAuth manager instance
-func NewOAuthManager(cfg *config.Config, db *database.PostgresDB, cache *redis.Client, logger *log.Logger) (*OAuthManager, error) {
+// NewOAuthManager creates a new OAuth manager instance. db is an
+// *database.AtomicDB rather than a bare *gorm.DB so that a CredentialManager
+// rotating the underlying connection pool (see pkg/database/credentials.go)
+// is transparent to token lookups and refreshes.
+func NewOAuthManager(cfg *config.Config, db *database.AtomicDB, cache *redis.Client, logger *slog.Logger) (*OAuthManager, error) {
     if cfg == nil || db == nil || cache == nil || logger == nil {
         return nil, errors.New("all dependencies must be provided")
     }
@@ -72,98 +97,318 @@ func NewOAuthManager(cfg *config.Config, db *database.PostgresDB, cache *redis.C
     // Initialize metrics
     metrics = initializeMetrics()
 
-    // Initialize OAuth clients
-    oauthClients := make(map[string]*oauth2.Config)
-    
-    // Configure Zoho CRM OAuth client
-    oauthClients[models.ServiceTypeZohoCRM] = &oauth2.Config{
+    // Initialize the provider registry. zoho_crm is always registered from
+    // ZohoCRMConfig so existing integrations keep working unchanged;
+    // cfg.OAuthProviders registers everything else (Google, Salesforce,
+    // HubSpot, Slack, GitHub, Microsoft, ...) declaratively, and
+    // OAuthManager.RegisterProvider lets callers add more at runtime.
+    registry := NewProviderRegistry()
+    registry.RegisterProvider(models.ServiceTypeZohoCRM, ProviderConfig{
         ClientID:     cfg.ZohoCRMConfig.ClientID,
         ClientSecret: cfg.ZohoCRMConfig.ClientSecret,
-        Endpoint: oauth2.Endpoint{
-            AuthURL:  fmt.Sprintf("%s/oauth/v2/auth", cfg.ZohoCRMConfig.BaseURL),
-            TokenURL: fmt.Sprintf("%s/oauth/v2/token", cfg.ZohoCRMConfig.BaseURL),
-        },
+        AuthURL:      fmt.Sprintf("%s/oauth/v2/auth", cfg.ZohoCRMConfig.BaseURL),
+        TokenURL:     fmt.Sprintf("%s/oauth/v2/token", cfg.ZohoCRMConfig.BaseURL),
+    })
+    for name, p := range cfg.OAuthProviders {
+        registry.RegisterProvider(name, ProviderConfig{
+            ClientID:        p.ClientID,
+            ClientSecret:    p.ClientSecret,
+            AuthURL:         p.AuthURL,
+            TokenURL:        p.TokenURL,
+            RedirectURL:     p.RedirectURL,
+            Scopes:          p.Scopes,
+            UsePKCE:         p.UsePKCE,
+            DeviceAuthURL:   p.DeviceAuthURL,
+            RegistrationURL: p.RegistrationURL,
+        })
     }
 
-    // Initialize rate limiter
+    // Initialize the rate limiter. zoho_crm gets its own token-bucket
+    // policy with headroom for the short bursts agentx-sync's periodic
+    // refresh sweeps cause; every other provider falls back to the
+    // window-based default until a caller RegisterPolicy's its own quota.
     rateLimiter := newRateLimiter(cache, rateLimitWindow, maxRequestsPerWindow)
+    rateLimiter.RegisterPolicy(Policy{
+        ProviderName:    models.ServiceTypeZohoCRM,
+        Capacity:        float64(maxRequestsPerWindow),
+        RefillPerSec:    float64(maxRequestsPerWindow) / rateLimitWindow.Seconds(),
+        BurstMultiplier: 1.5,
+    })
+
+    // Seal stored tokens with whichever key source config itself decrypts
+    // secrets with, falling back to a single-version provider over the
+    // already-validated EncryptionKey when no SecurityConfig.KeyProvider
+    // URL is configured.
+    keyProvider := cfg.KeyProvider()
+    if keyProvider == nil {
+        keyProvider = staticKeyProvider{key: encryptionKey}
+    }
+    tokenCrypto := NewTokenCrypto(keyProvider, db)
 
     return &OAuthManager{
         config:       cfg,
         db:           db,
         cache:        cache,
         logger:       logger,
-        oauthClients: oauthClients,
+        registry:     registry,
+        tokenCrypto:  tokenCrypto,
         rateLimiter:  rateLimiter,
         metrics:      metrics,
+        tenantCrypto: make(map[string]*TokenCrypto),
     }, nil
 }
 
-// GetToken retrieves a valid OAuth token for the specified integration
+// loggerFor prefers the request-scoped logger ctx carries (see
+// logging.RequestID, which tags it with a correlation ID) and falls back
+// to m.logger, the one NewOAuthManager was built with, for callers like
+// cmd/agentx-sync that never put one on ctx.
+func (m *OAuthManager) loggerFor(ctx context.Context) *slog.Logger {
+    if logger := logging.FromContext(ctx); logger != slog.Default() {
+        return logger
+    }
+    return m.logger
+}
+
+// RegisterProvider adds or replaces an OAuth provider at runtime, in
+// addition to the ones NewOAuthManager loaded from config.
+func (m *OAuthManager) RegisterProvider(name string, cfg ProviderConfig) {
+    m.registry.RegisterProvider(name, cfg)
+}
+
+// RegisterPolicy sets the token-bucket rate-limit quota for an OAuth
+// provider, in addition to the zoho_crm policy NewOAuthManager always
+// configures. Providers without a registered policy fall back to the
+// shared window-based default.
+func (m *OAuthManager) RegisterPolicy(policy Policy) {
+    m.rateLimiter.RegisterPolicy(policy)
+}
+
+// Config returns the *config.Config this manager was built from, so that
+// connector factories (which receive an *OAuthManager but not cfg itself)
+// can read integration-specific settings like ZohoCRMConfig's reliability
+// knobs without a separate config plumbing path.
+func (m *OAuthManager) Config() *config.Config {
+    return m.config
+}
+
+// Cache returns the *redis.Client this manager rate-limits and caches
+// tokens through, so callers outside pkg/auth — the admin listener's
+// readiness check, for one — can probe Redis reachability without
+// standing up a second client.
+func (m *OAuthManager) Cache() *redis.Client {
+    return m.cache
+}
+
+// tokenCryptoFor lazily resolves and caches the *TokenCrypto that seals
+// tenantID's tokens: each tenant's DEK is wrapped under its own KEK, per
+// SecurityConfig.TenantKeyProviderTemplate, so a compromised key only
+// exposes the one tenant it belongs to. Tenants with no template configured
+// — and the empty tenant ID pre-tenancy records still carry — fall back to
+// the shared TokenCrypto NewOAuthManager built from SecurityConfig's
+// top-level KeyProvider/EncryptionKey.
+func (m *OAuthManager) tokenCryptoFor(ctx context.Context, tenantID string) (*TokenCrypto, error) {
+    if tenantID == "" || m.config.SecurityConfig.TenantKeyProviderTemplate == "" {
+        return m.tokenCrypto, nil
+    }
+
+    m.tenantCryptoMu.RLock()
+    tc, ok := m.tenantCrypto[tenantID]
+    m.tenantCryptoMu.RUnlock()
+    if ok {
+        return tc, nil
+    }
+
+    m.tenantCryptoMu.Lock()
+    defer m.tenantCryptoMu.Unlock()
+    if tc, ok := m.tenantCrypto[tenantID]; ok {
+        return tc, nil
+    }
+
+    url := strings.ReplaceAll(m.config.SecurityConfig.TenantKeyProviderTemplate, "{tenant}", tenantID)
+    provider, err := secrets.NewProvider(url)
+    if err != nil {
+        return nil, fmt.Errorf("auth: resolving key provider for tenant %s: %w", tenantID, err)
+    }
+
+    tc = NewTokenCrypto(provider, m.db)
+    m.tenantCrypto[tenantID] = tc
+    return tc, nil
+}
+
+// cacheKeyFor builds the Redis key a tenant's integration's cached token is
+// stored under. Scoping it by tenant, rather than integration ID alone,
+// lets PurgeTenant delete exactly one tenant's cache entries and keeps a
+// leaked key from being replayed onto another tenant's cache slot.
+func cacheKeyFor(tenantID string, integrationID uuid.UUID) string {
+    return fmt.Sprintf("%s%s:%s", cacheKeyPrefix, tenantID, integrationID)
+}
+
+// GetToken retrieves a valid OAuth token for the specified integration. It
+// trusts integrationID outright, with no tenant to check it against; use
+// this only from contexts that do not carry a caller-claimed tenant (the
+// OAuth HTTP flow's own authenticator, agentx-sync's internal sweeps).
+// Callers that do have a tenant should use GetTokenForTenant instead, so a
+// caller from tenant A can't be handed tenant B's token by supplying B's
+// integration ID.
 func (m *OAuthManager) GetToken(ctx context.Context, integrationID uuid.UUID) (*oauth2.Token, error) {
+    return m.getToken(ctx, integrationID, "")
+}
+
+// GetTokenForTenant is GetToken's tenant-enforcing counterpart: it rejects
+// the request with ErrCrossTenantAccess if integrationID does not belong to
+// tenantID.
+func (m *OAuthManager) GetTokenForTenant(ctx context.Context, tenantID string, integrationID uuid.UUID) (*oauth2.Token, error) {
+    if tenantID == "" {
+        return nil, errors.New("auth: tenant ID is required")
+    }
+    return m.getToken(ctx, integrationID, tenantID)
+}
+
+// getToken is GetToken and GetTokenForTenant's shared implementation.
+// claimedTenant is "" for the tenant-unaware GetToken path, and is enforced
+// against the integration's actual TenantID otherwise.
+//
+// It runs a cheap Select("tenant_id", "service_type") lookup before
+// touching the cache, so the cache key, metrics labels, and rate-limit
+// bucket are all tenant-scoped even on a cache hit. That costs one small
+// extra query on the hit path that the pre-multi-tenancy version didn't
+// need, in exchange for tenant isolation holding all the way through.
+func (m *OAuthManager) getToken(ctx context.Context, integrationID uuid.UUID, claimedTenant string) (*oauth2.Token, error) {
     start := time.Now()
+
+    // Resolve just enough of the integration to scope everything else,
+    // participating in a caller's transaction (see database.WithTx) when
+    // ctx carries one, and resolving m.db's current pool fresh in case a
+    // CredentialManager has rotated it.
+    db := database.DBFromContext(ctx, m.db.Get())
+    var integration models.Integration
+    if err := db.Select("id", "tenant_id", "service_type").First(&integration, "id = ?", integrationID).Error; err != nil {
+        m.metrics.errors.WithLabelValues(claimedTenant, "").Inc()
+        return nil, fmt.Errorf("failed to get integration: %w", err)
+    }
+    tenantID := integration.TenantID
+
+    if claimedTenant != "" && tenantID != claimedTenant {
+        m.metrics.errors.WithLabelValues(claimedTenant, integration.ServiceType).Inc()
+        return nil, fmt.Errorf("%w: integration %s", ErrCrossTenantAccess, integrationID)
+    }
+
     defer func() {
-        m.metrics.responseTime.Observe(time.Since(start).Seconds())
+        m.metrics.responseTime.WithLabelValues(tenantID, integration.ServiceType).Observe(time.Since(start).Seconds())
     }()
+    m.metrics.tokenRequests.WithLabelValues(tenantID, integration.ServiceType).Inc()
 
-    m.metrics.tokenRequests.Inc()
-
-    // Check rate limit
-    if err := m.rateLimiter.checkLimit(ctx, integrationID.String()); err != nil {
-        return nil, fmt.Errorf("rate limit exceeded: %w", err)
+    tokenCrypto, err := m.tokenCryptoFor(ctx, tenantID)
+    if err != nil {
+        m.metrics.errors.WithLabelValues(tenantID, integration.ServiceType).Inc()
+        return nil, err
     }
 
     // Try to get token from cache
-    cacheKey := fmt.Sprintf("%s%s", cacheKeyPrefix, integrationID)
-    tokenData, err := m.cache.Get(ctx, cacheKey).Bytes()
-    if err == nil {
-        var token oauth2.Token
-        if err := json.Unmarshal(tokenData, &token); err == nil && token.Valid() {
-            return &token, nil
+    cacheKey := cacheKeyFor(tenantID, integrationID)
+    if cached, err := m.cache.Get(ctx, cacheKey).Bytes(); err == nil {
+        if token, err := tokenCrypto.Decrypt(ctx, cached); err == nil && token.Valid() {
+            return token, nil
         }
     }
 
-    // Get integration from database
-    var integration models.Integration
-    if err := m.db.First(&integration, "id = ?", integrationID).Error; err != nil {
-        m.metrics.errors.Inc()
+    // Get the full integration record now that the cache missed.
+    if err := db.First(&integration, "id = ?", integrationID).Error; err != nil {
+        m.metrics.errors.WithLabelValues(tenantID, integration.ServiceType).Inc()
         return nil, fmt.Errorf("failed to get integration: %w", err)
     }
 
-    // Get OAuth client
-    client, ok := m.oauthClients[integration.ServiceType]
+    // Get the OAuth client for this integration's service type, dispatched
+    // through the provider registry rather than a static map so providers
+    // registered via config or RegisterProvider are reachable here too.
+    providerCfg, ok := m.registry.Provider(integration.ServiceType)
     if !ok {
-        m.metrics.errors.Inc()
+        m.metrics.errors.WithLabelValues(tenantID, integration.ServiceType).Inc()
         return nil, fmt.Errorf("unsupported service type: %s", integration.ServiceType)
     }
+    client := providerCfg.oauth2Config()
+
+    // Check the provider's token-bucket quota, keyed by (provider, tenant,
+    // integration) so one noisy integration's refreshes can't starve
+    // another integration, tenant-scoped in case two tenants somehow share
+    // an integration ID collision window.
+    rateLimitKey := tenantID + ":" + integrationID.String()
+    if err := m.rateLimiter.checkLimit(ctx, integration.ServiceType, rateLimitKey); err != nil {
+        m.metrics.errors.WithLabelValues(tenantID, integration.ServiceType).Inc()
+        m.loggerFor(ctx).Warn("auth: rate limit exceeded", "integration_id", integrationID, "tenant_id", tenantID, "service_type", integration.ServiceType)
+        return nil, err
+    }
 
     // Parse stored token
-    var storedToken oauth2.Token
-    if err := json.Unmarshal(integration.Config, &storedToken); err != nil {
-        m.metrics.errors.Inc()
+    storedToken, err := tokenCrypto.Decrypt(ctx, integration.Config)
+    if err != nil {
+        m.metrics.errors.WithLabelValues(tenantID, integration.ServiceType).Inc()
         return nil, fmt.Errorf("failed to parse stored token: %w", err)
     }
 
     // Check if token needs refresh
     if time.Until(storedToken.Expiry) < tokenExpiryBuffer {
-        newToken, err := m.refreshToken(ctx, client, &storedToken, &integration)
+        m.loggerFor(ctx).Info("auth: refreshing oauth token", "integration_id", integrationID, "tenant_id", tenantID, "service_type", integration.ServiceType)
+        newToken, err := m.refreshToken(ctx, client, storedToken, &integration, tokenCrypto, rateLimitKey)
         if err != nil {
-            m.metrics.errors.Inc()
+            m.metrics.errors.WithLabelValues(tenantID, integration.ServiceType).Inc()
             return nil, fmt.Errorf("failed to refresh token: %w", err)
         }
-        storedToken = *newToken
+        storedToken = newToken
     }
 
     // Cache the valid token
-    tokenBytes, _ := json.Marshal(storedToken)
-    m.cache.Set(ctx, cacheKey, tokenBytes, cacheExpiration)
+    sealed, err := tokenCrypto.Encrypt(ctx, storedToken, integrationID[:])
+    if err != nil {
+        m.metrics.errors.WithLabelValues(tenantID, integration.ServiceType).Inc()
+        return nil, fmt.Errorf("failed to seal token for cache: %w", err)
+    }
+    m.cache.Set(ctx, cacheKey, []byte(sealed), cacheExpiration)
 
-    return &storedToken, nil
+    return storedToken, nil
 }
 
-// refreshToken handles token refresh with retry logic
-func (m *OAuthManager) refreshToken(ctx context.Context, client *oauth2.Config, token *oauth2.Token, integration *models.Integration) (*oauth2.Token, error) {
-    m.metrics.tokenRefreshes.Inc()
+// PurgeTenant removes tenantID's cached state from Redis — its token cache
+// entries and rate-limit buckets for every integration it owns — and drops
+// its resolved TokenCrypto from tenantCrypto, for a tenant-deletion
+// workflow to call once it has deleted the tenant's integrations from
+// Postgres. It does not touch Postgres itself, and does not unwrap or
+// revoke the tenant's KMS key, which is a provider-side operation outside
+// this package's reach.
+func (m *OAuthManager) PurgeTenant(ctx context.Context, tenantID string) error {
+    if tenantID == "" {
+        return errors.New("auth: tenant ID is required")
+    }
+
+    var integrations []models.Integration
+    if err := m.db.Get().WithContext(ctx).Where("tenant_id = ?", tenantID).Find(&integrations).Error; err != nil {
+        return fmt.Errorf("auth: listing integrations for tenant %s: %w", tenantID, err)
+    }
+
+    for _, integration := range integrations {
+        if err := m.cache.Del(ctx, cacheKeyFor(tenantID, integration.ID)).Err(); err != nil {
+            return fmt.Errorf("auth: purging cached token for integration %s: %w", integration.ID, err)
+        }
+        rateLimitKey := tenantID + ":" + integration.ID.String()
+        if err := m.rateLimiter.reset(ctx, integration.ServiceType, rateLimitKey); err != nil {
+            return fmt.Errorf("auth: purging rate limit bucket for integration %s: %w", integration.ID, err)
+        }
+    }
+
+    m.tenantCryptoMu.Lock()
+    delete(m.tenantCrypto, tenantID)
+    m.tenantCryptoMu.Unlock()
+
+    return nil
+}
+
+// refreshToken handles token refresh with retry logic. A 429 response
+// drains the provider's rate-limit bucket via Penalize for however long
+// the response's own Retry-After says, on top of this loop's exponential
+// backoff with jitter, so a provider-side quota the local token bucket
+// didn't know about still slows subsequent callers down.
+func (m *OAuthManager) refreshToken(ctx context.Context, client *oauth2.Config, token *oauth2.Token, integration *models.Integration, tokenCrypto *TokenCrypto, rateLimitKey string) (*oauth2.Token, error) {
+    m.metrics.tokenRefreshes.WithLabelValues(integration.TenantID, integration.ServiceType).Inc()
 
     var newToken *oauth2.Token
     var err error
@@ -174,75 +419,167 @@ func (m *OAuthManager) refreshToken(ctx context.Context, client *oauth2.Config,
             break
         }
 
+        wait := time.Duration(1<<uint(attempt-1)) * refreshBackoffBase
+        if retryAfter, ok := retryAfterFromError(err); ok {
+            m.rateLimiter.Penalize(ctx, integration.ServiceType, rateLimitKey, retryAfter)
+            wait = retryAfter
+            m.loggerFor(ctx).Warn("auth: provider rate limited token refresh", "integration_id", integration.ID, "attempt", attempt, "retry_after", retryAfter)
+        } else {
+            m.loggerFor(ctx).Warn("auth: token refresh attempt failed", "integration_id", integration.ID, "attempt", attempt, "error", err)
+        }
+
         if attempt == maxRetries {
             return nil, err
         }
 
-        time.Sleep(time.Duration(attempt) * 100 * time.Millisecond)
+        wait += time.Duration(rand.Int63n(int64(refreshBackoffBase)))
+        select {
+        case <-ctx.Done():
+            return nil, ctx.Err()
+        case <-time.After(wait):
+        }
+    }
+
+    // Update integration with new token, in the same transaction (if any)
+    // the caller supplied via ctx.
+    sealed, err := tokenCrypto.Encrypt(ctx, newToken, integration.ID[:])
+    if err != nil {
+        return nil, fmt.Errorf("failed to seal refreshed token: %w", err)
     }
+    integration.Config = sealed
 
-    // Update integration with new token
-    tokenBytes, _ := json.Marshal(newToken)
-    integration.Config = tokenBytes
-    
-    if err := m.db.Save(integration).Error; err != nil {
+    if err := database.DBFromContext(ctx, m.db.Get()).Save(integration).Error; err != nil {
         return nil, fmt.Errorf("failed to save refreshed token: %w", err)
     }
 
     return newToken, nil
 }
 
-// initializeMetrics sets up Prometheus metrics
-func initializeMetrics() *oauthMetrics {
-    return &oauthMetrics{
-        tokenRequests: prometheus.NewCounter(prometheus.CounterOpts{
-            Name: "oauth_token_requests_total",
-            Help: "Total number of OAuth token requests",
-        }),
-        tokenRefreshes: prometheus.NewCounter(prometheus.CounterOpts{
-            Name: "oauth_token_refreshes_total",
-            Help: "Total number of OAuth token refreshes",
-        }),
-        errors: prometheus.NewCounter(prometheus.CounterOpts{
-            Name: "oauth_errors_total",
-            Help: "Total number of OAuth errors",
-        }),
-        responseTime: prometheus.NewHistogram(prometheus.HistogramOpts{
-            Name:    "oauth_response_time_seconds",
-            Help:    "OAuth operation response time in seconds",
-            Buckets: prometheus.DefBuckets,
-        }),
+// StartAuthCodeFlow begins the authorization-code flow for provider,
+// returning the URL to redirect userID's browser to, the CSRF state, and
+// (when the provider is registered with UsePKCE) the code verifier the
+// HTTP layer must hold onto until ExchangeCode is called. OAuthManager
+// does not itself persist the (state, userID, codeVerifier) triple; the
+// HTTP layer is expected to stash it (session, signed cookie, cache) until
+// the provider redirects back.
+func (m *OAuthManager) StartAuthCodeFlow(ctx context.Context, provider string, userID uuid.UUID) (authURL, state, codeVerifier string, err error) {
+    providerCfg, ok := m.registry.Provider(provider)
+    if !ok {
+        return "", "", "", fmt.Errorf("auth: unknown oauth provider: %s", provider)
+    }
+    if err := m.ensureClientCredentials(ctx, &providerCfg); err != nil {
+        return "", "", "", err
+    }
+
+    state, err = generateState()
+    if err != nil {
+        return "", "", "", fmt.Errorf("auth: generating state: %w", err)
+    }
+
+    var opts []oauth2.AuthCodeOption
+    if providerCfg.UsePKCE {
+        codeVerifier, err = generatePKCEVerifier()
+        if err != nil {
+            return "", "", "", fmt.Errorf("auth: generating PKCE verifier: %w", err)
+        }
+        opts = append(opts,
+            oauth2.SetAuthURLParam("code_challenge", pkceChallengeS256(codeVerifier)),
+            oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+        )
     }
-}
 
-// rateLimiter implements rate limiting using Redis
-type rateLimiter struct {
-    cache    *redis.Client
-    window   time.Duration
-    maxLimit int
+    authURL = providerCfg.oauth2Config().AuthCodeURL(state, opts...)
+    return authURL, state, codeVerifier, nil
 }
 
-func newRateLimiter(cache *redis.Client, window time.Duration, maxLimit int) *rateLimiter {
-    return &rateLimiter{
-        cache:    cache,
-        window:   window,
-        maxLimit: maxLimit,
+// StartDeviceFlow begins the RFC 8628 device-authorization flow for
+// provider, returning the device/user codes and verification URL the HTTP
+// layer shows to the user while it polls the token endpoint.
+func (m *OAuthManager) StartDeviceFlow(ctx context.Context, provider string) (*DeviceAuthorization, error) {
+    providerCfg, ok := m.registry.Provider(provider)
+    if !ok {
+        return nil, fmt.Errorf("auth: unknown oauth provider: %s", provider)
+    }
+    if providerCfg.DeviceAuthURL == "" {
+        return nil, fmt.Errorf("auth: provider %q does not support the device-authorization flow", provider)
     }
+    if err := m.ensureClientCredentials(ctx, &providerCfg); err != nil {
+        return nil, err
+    }
+
+    return requestDeviceAuthorization(ctx, providerCfg)
 }
 
-func (r *rateLimiter) checkLimit(ctx context.Context, key string) error {
-    count, err := r.cache.Incr(ctx, "ratelimit:"+key).Result()
+// ExchangeCode completes the authorization-code flow StartAuthCodeFlow
+// began, trading code for a token. state is not used here (the HTTP layer
+// is expected to have already compared it against the value
+// StartAuthCodeFlow returned); it is accepted so call sites read clearly
+// and so a signature change isn't needed if state validation later moves
+// into this package.
+func (m *OAuthManager) ExchangeCode(ctx context.Context, provider, code, state, verifier string) (*oauth2.Token, error) {
+    providerCfg, ok := m.registry.Provider(provider)
+    if !ok {
+        return nil, fmt.Errorf("auth: unknown oauth provider: %s", provider)
+    }
+
+    var opts []oauth2.AuthCodeOption
+    if providerCfg.UsePKCE {
+        if verifier == "" {
+            return nil, errors.New("auth: provider requires a PKCE code_verifier")
+        }
+        opts = append(opts, oauth2.SetAuthURLParam("code_verifier", verifier))
+    }
+
+    token, err := providerCfg.oauth2Config().Exchange(ctx, code, opts...)
     if err != nil {
-        return fmt.Errorf("failed to check rate limit: %w", err)
+        return nil, fmt.Errorf("auth: exchanging code for provider %q: %w", provider, err)
     }
+    return token, nil
+}
 
-    if count == 1 {
-        r.cache.Expire(ctx, "ratelimit:"+key, r.window)
+// ensureClientCredentials lazily performs RFC 7591 dynamic client
+// registration against providerCfg.RegistrationURL the first time a
+// provider is used without a pre-provisioned ClientID, caching the result
+// back into the registry so later flows for the same provider skip it.
+func (m *OAuthManager) ensureClientCredentials(ctx context.Context, providerCfg *ProviderConfig) error {
+    if providerCfg.ClientID != "" || providerCfg.RegistrationURL == "" {
+        return nil
     }
 
-    if count > int64(r.maxLimit) {
-        return errors.New("rate limit exceeded")
+    clientID, clientSecret, err := registerDynamicClient(ctx, *providerCfg)
+    if err != nil {
+        return fmt.Errorf("auth: dynamic client registration for provider %q: %w", providerCfg.Name, err)
     }
 
+    providerCfg.ClientID = clientID
+    providerCfg.ClientSecret = clientSecret
+    m.registry.RegisterProvider(providerCfg.Name, *providerCfg)
     return nil
-}
\ No newline at end of file
+}
+
+// initializeMetrics sets up Prometheus metrics, labeled by tenant and
+// provider so operators can break traffic and SLOs down per tenant instead
+// of reading one global rate.
+func initializeMetrics() *oauthMetrics {
+    labels := []string{"tenant", "provider"}
+    return &oauthMetrics{
+        tokenRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Name: "oauth_token_requests_total",
+            Help: "Total number of OAuth token requests",
+        }, labels),
+        tokenRefreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Name: "oauth_token_refreshes_total",
+            Help: "Total number of OAuth token refreshes",
+        }, labels),
+        errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Name: "oauth_errors_total",
+            Help: "Total number of OAuth errors",
+        }, labels),
+        responseTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+            Name:    "oauth_response_time_seconds",
+            Help:    "OAuth operation response time in seconds",
+            Buckets: prometheus.DefBuckets,
+        }, labels),
+    }
+}
+
@@ -0,0 +1,38 @@
+package auth
+
+import (
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/base64"
+    "fmt"
+)
+
+// generateState returns a URL-safe random value for the OAuth2 "state"
+// parameter, which the HTTP layer must hold onto (alongside the userID
+// passed to StartAuthCodeFlow) to detect CSRF and replay on the callback.
+func generateState() (string, error) {
+    return randomURLSafeString(32)
+}
+
+// generatePKCEVerifier returns a PKCE (RFC 7636) code_verifier: 32 random
+// bytes, base64url-encoded without padding, comfortably within the spec's
+// 43-128 character range.
+func generatePKCEVerifier() (string, error) {
+    return randomURLSafeString(32)
+}
+
+// pkceChallengeS256 derives the S256 code_challenge for verifier. S256 is
+// the only transform implemented; providers registered with UsePKCE are
+// assumed to support it, as all of Google, GitHub, and Microsoft do.
+func pkceChallengeS256(verifier string) string {
+    sum := sha256.Sum256([]byte(verifier))
+    return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func randomURLSafeString(n int) (string, error) {
+    b := make([]byte, n)
+    if _, err := rand.Read(b); err != nil {
+        return "", fmt.Errorf("auth: reading random bytes: %w", err)
+    }
+    return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,215 @@
+package auth
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "net/http"
+    "strconv"
+    "sync"
+    "time"
+
+    "github.com/go-redis/redis/v8" // v8.11.5
+    "golang.org/x/oauth2"          // v0.7.0
+)
+
+// Policy configures one provider's token-bucket quota. Capacity is the
+// bucket's burst ceiling and RefillPerSec is the sustained steady-state
+// rate; BurstMultiplier, when set, scales Capacity above RefillPerSec for
+// providers whose API tolerates short bursts above their sustained rate.
+type Policy struct {
+    ProviderName    string
+    Capacity        float64
+    RefillPerSec    float64
+    BurstMultiplier float64
+}
+
+func (p Policy) capacity() float64 {
+    capacity := p.Capacity
+    if p.BurstMultiplier > 0 {
+        capacity *= p.BurstMultiplier
+    }
+    if capacity <= 0 {
+        capacity = 1
+    }
+    return capacity
+}
+
+// tokenBucketScript is an atomic Redis token-bucket limiter: it reads the
+// bucket's last token count and timestamp, refills it for elapsed time,
+// and either takes one token or reports how long the caller must wait for
+// one, all in a single EVAL so concurrent integration-service instances
+// never race on a read-modify-write of the same key the way the old
+// INCR+EXPIRE counter could.
+const tokenBucketScript = `
+local bucket_key = KEYS[1]
+local capacity = tonumber(ARGV[1])
+local refill_per_sec = tonumber(ARGV[2])
+local now_ms = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", bucket_key, "tokens", "updated_at")
+local tokens = tonumber(bucket[1])
+local updated_at = tonumber(bucket[2])
+if tokens == nil then
+    tokens = capacity
+    updated_at = now_ms
+end
+
+local elapsed_sec = math.max(0, now_ms - updated_at) / 1000
+tokens = math.min(capacity, tokens + elapsed_sec * refill_per_sec)
+
+local allowed = 0
+local retry_after_ms = 0
+if tokens >= 1 then
+    allowed = 1
+    tokens = tokens - 1
+else
+    retry_after_ms = math.ceil((1 - tokens) / refill_per_sec * 1000)
+end
+
+redis.call("HMSET", bucket_key, "tokens", tostring(tokens), "updated_at", tostring(now_ms))
+redis.call("PEXPIRE", bucket_key, math.ceil((capacity / refill_per_sec) * 1000) + 1000)
+
+return {allowed, tostring(tokens), retry_after_ms}
+`
+
+// RateLimitError is returned by rateLimiter.checkLimit when a bucket has
+// no tokens left. RetryAfter comes straight out of tokenBucketScript's own
+// refill math, so callers can surface it as an HTTP Retry-After header
+// instead of guessing a fixed backoff.
+type RateLimitError struct {
+    Key        string
+    RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+    return fmt.Sprintf("rate limit exceeded for %s, retry after %s", e.Key, e.RetryAfter)
+}
+
+// rateLimiter implements a distributed token-bucket rate limiter backed by
+// Redis, keyed per (provider, tenantID:integrationID) so each provider's quota is
+// tracked independently rather than sharing one global counter.
+type rateLimiter struct {
+    cache  *redis.Client
+    script *redis.Script
+
+    mu       sync.RWMutex
+    policies map[string]Policy
+    fallback Policy
+}
+
+// newRateLimiter builds a rateLimiter whose fallback policy, used for any
+// provider without a RegisterPolicy call, refills maxLimit tokens evenly
+// across window — the same rate the old INCR+EXPIRE limiter enforced.
+func newRateLimiter(cache *redis.Client, window time.Duration, maxLimit int) *rateLimiter {
+    return &rateLimiter{
+        cache:    cache,
+        script:   redis.NewScript(tokenBucketScript),
+        policies: make(map[string]Policy),
+        fallback: Policy{
+            ProviderName: "default",
+            Capacity:     float64(maxLimit),
+            RefillPerSec: float64(maxLimit) / window.Seconds(),
+        },
+    }
+}
+
+// RegisterPolicy sets the token-bucket quota for policy.ProviderName,
+// overriding the window-based fallback every other provider uses.
+func (r *rateLimiter) RegisterPolicy(policy Policy) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.policies[policy.ProviderName] = policy
+}
+
+func (r *rateLimiter) policyFor(provider string) Policy {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    if p, ok := r.policies[provider]; ok {
+        return p
+    }
+    return r.fallback
+}
+
+// checkLimit consumes one token from provider's bucket for key (a tenant
+// ID and integration ID joined by a colon), returning a *RateLimitError carrying a Retry-After hint
+// when the bucket is empty.
+func (r *rateLimiter) checkLimit(ctx context.Context, provider, key string) error {
+    policy := r.policyFor(provider)
+    capacity := policy.capacity()
+    refillPerSec := policy.RefillPerSec
+    if refillPerSec <= 0 {
+        refillPerSec = capacity
+    }
+
+    bucketKey := fmt.Sprintf("ratelimit:%s:%s", provider, key)
+    result, err := r.script.Run(ctx, r.cache, []string{bucketKey}, capacity, refillPerSec, time.Now().UnixMilli()).Result()
+    if err != nil {
+        return fmt.Errorf("failed to check rate limit: %w", err)
+    }
+
+    values, ok := result.([]interface{})
+    if !ok || len(values) != 3 {
+        return fmt.Errorf("unexpected rate limit script result: %v", result)
+    }
+    allowed, _ := values[0].(int64)
+    retryAfterMs, _ := values[2].(int64)
+
+    if allowed == 0 {
+        return &RateLimitError{Key: bucketKey, RetryAfter: time.Duration(retryAfterMs) * time.Millisecond}
+    }
+    return nil
+}
+
+// Penalize drains provider's bucket for key so it reports no tokens
+// available again until duration has elapsed, for when the provider's API
+// returns a 429 the local bucket didn't predict — a quota shared with
+// other non-integration-service clients, for example.
+func (r *rateLimiter) Penalize(ctx context.Context, provider, key string, duration time.Duration) error {
+    bucketKey := fmt.Sprintf("ratelimit:%s:%s", provider, key)
+    penalizedUntilMs := time.Now().Add(duration).UnixMilli()
+    if err := r.cache.HSet(ctx, bucketKey, "tokens", "0", "updated_at", strconv.FormatInt(penalizedUntilMs, 10)).Err(); err != nil {
+        return fmt.Errorf("failed to penalize rate limit bucket: %w", err)
+    }
+    r.cache.PExpire(ctx, bucketKey, duration+time.Second)
+    return nil
+}
+
+// reset deletes provider's bucket for key outright, as opposed to Penalize
+// which drains it for a bounded duration: PurgeTenant uses this because once
+// a tenant is gone the bucket itself, not just its tokens, should stop
+// existing.
+func (r *rateLimiter) reset(ctx context.Context, provider, key string) error {
+    bucketKey := fmt.Sprintf("ratelimit:%s:%s", provider, key)
+    if err := r.cache.Del(ctx, bucketKey).Err(); err != nil {
+        return fmt.Errorf("failed to reset rate limit bucket: %w", err)
+    }
+    return nil
+}
+
+// retryAfterFromError extracts a Retry-After duration from err when it
+// wraps an *oauth2.RetrieveError carrying an HTTP 429 response, mirroring
+// reliability.retryAfter's header parsing for the token endpoint's own
+// rate limiting (separate from the provider API's, which
+// reliability.Config already governs for internal/services/zoho and rms).
+func retryAfterFromError(err error) (time.Duration, bool) {
+    var retrieveErr *oauth2.RetrieveError
+    if !errors.As(err, &retrieveErr) || retrieveErr.Response == nil {
+        return 0, false
+    }
+    if retrieveErr.Response.StatusCode != http.StatusTooManyRequests {
+        return 0, false
+    }
+
+    value := retrieveErr.Response.Header.Get("Retry-After")
+    if value == "" {
+        return 0, false
+    }
+    if seconds, err := strconv.Atoi(value); err == nil {
+        return time.Duration(seconds) * time.Second, true
+    }
+    if when, err := http.ParseTime(value); err == nil {
+        return time.Until(when), true
+    }
+    return 0, false
+}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+    "context"
+    "errors"
+    "net/http"
+)
+
+// APIKeyAuthenticator sets a static API key header on outgoing requests.
+type APIKeyAuthenticator struct {
+    Header string
+    Key    string
+}
+
+// NewAPIKeyAuthenticator creates an Authenticator that sets header to key on
+// every request.
+func NewAPIKeyAuthenticator(header, key string) *APIKeyAuthenticator {
+    return &APIKeyAuthenticator{Header: header, Key: key}
+}
+
+// Apply implements Authenticator.
+func (a *APIKeyAuthenticator) Apply(ctx context.Context, req *http.Request) error {
+    if a.Key == "" {
+        return errors.New("apikey authenticator: key is empty")
+    }
+    header := a.Header
+    if header == "" {
+        header = "X-API-Key"
+    }
+    req.Header.Set(header, a.Key)
+    return nil
+}
@@ -0,0 +1,28 @@
+package auth
+
+import (
+    "context"
+    "errors"
+    "net/http"
+)
+
+// BasicAuthenticator applies HTTP Basic authentication to outgoing requests.
+type BasicAuthenticator struct {
+    Username string
+    Password string
+}
+
+// NewBasicAuthenticator creates an Authenticator that sets the
+// Authorization header using username/password on every request.
+func NewBasicAuthenticator(username, password string) *BasicAuthenticator {
+    return &BasicAuthenticator{Username: username, Password: password}
+}
+
+// Apply implements Authenticator.
+func (a *BasicAuthenticator) Apply(ctx context.Context, req *http.Request) error {
+    if a.Username == "" {
+        return errors.New("basic authenticator: username is empty")
+    }
+    req.SetBasicAuth(a.Username, a.Password)
+    return nil
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+    "context"
+    "crypto/tls"
+    "crypto/x509"
+    "fmt"
+    "net/http"
+    "os"
+)
+
+// MTLSAuthenticator is a marker Authenticator for integrations that
+// authenticate via a client TLS certificate rather than anything carried in
+// the request itself. Its Apply is a no-op: the actual authentication
+// happens during the TLS handshake, via an http.Transport built with
+// NewMTLSTransport. It exists so mTLS can still be named as a scheme and
+// composed alongside others (e.g. an API key gate in front of an mTLS
+// connection) without every caller special-casing "no authenticator needed".
+type MTLSAuthenticator struct{}
+
+// NewMTLSAuthenticator creates a no-op Authenticator for mTLS-secured
+// integrations. Callers must also build their *http.Client from
+// NewMTLSTransport for the certificate to actually be presented.
+func NewMTLSAuthenticator() *MTLSAuthenticator {
+    return &MTLSAuthenticator{}
+}
+
+// Apply implements Authenticator. It intentionally does nothing; see the
+// MTLSAuthenticator doc comment.
+func (a *MTLSAuthenticator) Apply(ctx context.Context, req *http.Request) error {
+    return nil
+}
+
+// NewMTLSTransport builds an *http.Transport that presents the client
+// certificate at certFile/keyFile during the TLS handshake, verifying the
+// server against caFile when provided (the system root pool is used
+// otherwise).
+func NewMTLSTransport(certFile, keyFile, caFile string) (*http.Transport, error) {
+    cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+    if err != nil {
+        return nil, fmt.Errorf("mtls: failed to load client certificate: %w", err)
+    }
+
+    tlsConfig := &tls.Config{
+        Certificates: []tls.Certificate{cert},
+        MinVersion:   tls.VersionTLS12,
+    }
+
+    if caFile != "" {
+        caCert, err := os.ReadFile(caFile)
+        if err != nil {
+            return nil, fmt.Errorf("mtls: failed to read CA certificate: %w", err)
+        }
+        pool := x509.NewCertPool()
+        if !pool.AppendCertsFromPEM(caCert) {
+            return nil, fmt.Errorf("mtls: failed to parse CA certificate from %s", caFile)
+        }
+        tlsConfig.RootCAs = pool
+    }
+
+    return &http.Transport{TLSClientConfig: tlsConfig}, nil
+}
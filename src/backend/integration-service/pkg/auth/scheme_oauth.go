@@ -0,0 +1,32 @@
+package auth
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+
+    "github.com/google/uuid"
+)
+
+// OAuthAuthenticator applies an OAuth 2.0 bearer token to outgoing requests,
+// refreshing it through OAuthManager.GetToken as needed.
+type OAuthAuthenticator struct {
+    manager       *OAuthManager
+    integrationID uuid.UUID
+}
+
+// NewOAuthAuthenticator creates an Authenticator backed by manager for a
+// specific integration.
+func NewOAuthAuthenticator(manager *OAuthManager, integrationID uuid.UUID) *OAuthAuthenticator {
+    return &OAuthAuthenticator{manager: manager, integrationID: integrationID}
+}
+
+// Apply implements Authenticator.
+func (a *OAuthAuthenticator) Apply(ctx context.Context, req *http.Request) error {
+    token, err := a.manager.GetToken(ctx, a.integrationID)
+    if err != nil {
+        return fmt.Errorf("oauth2 authenticator: %w", err)
+    }
+    req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
+    return nil
+}
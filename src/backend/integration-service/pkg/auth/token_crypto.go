@@ -0,0 +1,200 @@
+package auth
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+
+    "golang.org/x/oauth2"                            // v0.7.0
+    "github.com/prometheus/client_golang/prometheus" // v1.14.0
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/secrets"
+)
+
+// sealedTokenEnvelope is the JSON shape a TokenCrypto-sealed token is
+// stored in, both in Integration.Config and in the Redis cache, so the
+// value stays a JSON object (the generic part of
+// models.Integration.validateConfig still passes) even though its payload
+// is now opaque ciphertext.
+type sealedTokenEnvelope struct {
+    Sealed string `json:"sealed_token"`
+}
+
+// tokenCryptoMetrics tracks TokenCrypto operations, following the same
+// one-counter-per-outcome style as oauthMetrics, plus a counter for how
+// many integrations RotateKeys has re-sealed under a new key.
+type tokenCryptoMetrics struct {
+    encryptOps prometheus.Counter
+    decryptOps prometheus.Counter
+    errors     prometheus.Counter
+    rotations  prometheus.Counter
+}
+
+func newTokenCryptoMetrics() *tokenCryptoMetrics {
+    return &tokenCryptoMetrics{
+        encryptOps: prometheus.NewCounter(prometheus.CounterOpts{
+            Name: "oauth_token_encrypt_total",
+            Help: "Total number of OAuth tokens envelope-encrypted at rest.",
+        }),
+        decryptOps: prometheus.NewCounter(prometheus.CounterOpts{
+            Name: "oauth_token_decrypt_total",
+            Help: "Total number of OAuth tokens envelope-decrypted from rest.",
+        }),
+        errors: prometheus.NewCounter(prometheus.CounterOpts{
+            Name: "oauth_token_crypto_errors_total",
+            Help: "Total number of TokenCrypto encrypt/decrypt failures.",
+        }),
+        rotations: prometheus.NewCounter(prometheus.CounterOpts{
+            Name: "oauth_token_key_rotations_total",
+            Help: "Number of times RotateKeys has re-sealed stored tokens under a new key.",
+        }),
+    }
+}
+
+// TokenCrypto envelope-encrypts OAuth tokens at rest, in both
+// Integration.Config and the Redis token cache, building on
+// pkg/secrets.Sealer/KeyProvider rather than a second AES-256-GCM
+// implementation: every Encrypt call seals the token under a fresh
+// per-token DEK wrapped with the KeyProvider's current KEK (tagged with its
+// key ID), so RotateKeys opens each stored token under the KEK its envelope
+// names and re-seals it, with a new DEK, under the current KEK.
+type TokenCrypto struct {
+    sealer  *secrets.Sealer
+    db      *database.AtomicDB
+    metrics *tokenCryptoMetrics
+}
+
+// NewTokenCrypto builds a TokenCrypto that seals/opens values with
+// provider. db is used only by RotateKeys, to walk every OAuth
+// integration's stored token.
+func NewTokenCrypto(provider secrets.KeyProvider, db *database.AtomicDB) *TokenCrypto {
+    return &TokenCrypto{
+        sealer:  secrets.NewSealer(provider),
+        db:      db,
+        metrics: newTokenCryptoMetrics(),
+    }
+}
+
+// Encrypt seals token, authenticating aad (the integration ID) alongside
+// the ciphertext. The aad is stored inside the sealed envelope and Decrypt
+// authenticates against that stored copy; it is not compared with the
+// integration whose record or cache entry the token is read from.
+func (c *TokenCrypto) Encrypt(ctx context.Context, token *oauth2.Token, aad []byte) (json.RawMessage, error) {
+    plaintext, err := json.Marshal(token)
+    if err != nil {
+        c.metrics.errors.Inc()
+        return nil, fmt.Errorf("auth: marshaling token: %w", err)
+    }
+
+    sealed, err := c.sealer.Seal(ctx, string(plaintext), aad)
+    if err != nil {
+        c.metrics.errors.Inc()
+        return nil, fmt.Errorf("auth: sealing token: %w", err)
+    }
+
+    envelope, err := json.Marshal(sealedTokenEnvelope{Sealed: sealed})
+    if err != nil {
+        c.metrics.errors.Inc()
+        return nil, fmt.Errorf("auth: marshaling sealed token envelope: %w", err)
+    }
+
+    c.metrics.encryptOps.Inc()
+    return envelope, nil
+}
+
+// Decrypt reverses Encrypt. Integrations whose Config predates TokenCrypto
+// (an unencrypted oauth2.Token, or the connector's own creation-time
+// Config shape) decode directly instead, the same tolerant way GetToken
+// always has handled them, matching
+// config.decryptSensitiveValues's legacy-plaintext fallback for the same
+// reason: a key rotation or encryption rollout shouldn't break records
+// written before it.
+func (c *TokenCrypto) Decrypt(ctx context.Context, raw json.RawMessage) (*oauth2.Token, error) {
+    var envelope sealedTokenEnvelope
+    if err := json.Unmarshal(raw, &envelope); err == nil && envelope.Sealed != "" {
+        plaintext, err := c.sealer.Open(ctx, envelope.Sealed)
+        if err != nil {
+            c.metrics.errors.Inc()
+            return nil, fmt.Errorf("auth: opening sealed token: %w", err)
+        }
+
+        var token oauth2.Token
+        if err := json.Unmarshal([]byte(plaintext), &token); err != nil {
+            c.metrics.errors.Inc()
+            return nil, fmt.Errorf("auth: unmarshaling sealed token: %w", err)
+        }
+        c.metrics.decryptOps.Inc()
+        return &token, nil
+    }
+
+    var token oauth2.Token
+    if err := json.Unmarshal(raw, &token); err != nil {
+        c.metrics.errors.Inc()
+        return nil, fmt.Errorf("auth: unmarshaling token: %w", err)
+    }
+    return &token, nil
+}
+
+// RotateKeys re-seals every OAuth integration's stored token under its
+// tenant's current key: it decrypts each with whichever key it was last
+// sealed under (secrets.Sealer.Open resolves that from the sealed value's
+// own KEK ID) and re-encrypts with Encrypt, which always seals against the
+// tenant's current key (see tokenCryptoFor). Integrations whose
+// ServiceType has no registered OAuth provider (RMS, API-key-authenticated
+// connectors in general) are skipped: their Config is never TokenCrypto's
+// to rewrite.
+func (m *OAuthManager) RotateKeys(ctx context.Context) error {
+    var integrations []models.Integration
+    if err := m.db.Get().WithContext(ctx).Find(&integrations).Error; err != nil {
+        return fmt.Errorf("auth: listing integrations for key rotation: %w", err)
+    }
+
+    for i := range integrations {
+        integration := &integrations[i]
+        if _, ok := m.registry.Provider(integration.ServiceType); !ok {
+            continue
+        }
+
+        tokenCrypto, err := m.tokenCryptoFor(ctx, integration.TenantID)
+        if err != nil {
+            return fmt.Errorf("auth: resolving key for integration %s: %w", integration.ID, err)
+        }
+
+        token, err := tokenCrypto.Decrypt(ctx, integration.Config)
+        if err != nil {
+            return fmt.Errorf("auth: rotating key for integration %s: %w", integration.ID, err)
+        }
+
+        sealed, err := tokenCrypto.Encrypt(ctx, token, integration.ID[:])
+        if err != nil {
+            return fmt.Errorf("auth: rotating key for integration %s: %w", integration.ID, err)
+        }
+
+        integration.Config = sealed
+        if err := m.db.Get().WithContext(ctx).Save(integration).Error; err != nil {
+            return fmt.Errorf("auth: saving rotated token for integration %s: %w", integration.ID, err)
+        }
+
+        tokenCrypto.metrics.rotations.Inc()
+    }
+
+    return nil
+}
+
+// staticKeyProvider wraps a single, already-resolved key for installations
+// that still configure SecurityConfig.EncryptionKey directly rather than a
+// SecurityConfig.KeyProvider URL. It has exactly one key version, "v1",
+// matching the single-key file/env providers in pkg/secrets.
+type staticKeyProvider struct {
+    key []byte
+}
+
+// Key implements secrets.KeyProvider.
+func (p staticKeyProvider) Key(ctx context.Context, keyID string) ([]byte, string, error) {
+    if keyID != "" && keyID != "v1" {
+        return nil, "", fmt.Errorf("auth: static key provider has no key version %q", keyID)
+    }
+    return p.key, "v1", nil
+}
@@ -0,0 +1,46 @@
+package connectors
+
+import (
+    "context"
+    "time"
+)
+
+// ListOptions parameterizes a single page of a Reader.ListRecords call.
+type ListOptions struct {
+    // Since restricts results to records changed at or after this time. The
+    // zero value means "no lower bound".
+    Since time.Time
+
+    // Cursor resumes a previous ListRecords call; "" starts from the
+    // beginning. Its format is connector-specific and opaque to callers.
+    Cursor string
+
+    // Limit caps the number of records returned in this page.
+    Limit int
+}
+
+// ListResult is a single page of records from a Reader.ListRecords call.
+type ListResult struct {
+    Records []map[string]interface{}
+
+    // NextCursor resumes after this page; it is only meaningful when Done
+    // is false.
+    NextCursor string
+
+    // Done reports whether this was the last page.
+    Done bool
+}
+
+// Reader is implemented by connectors that can stream their records a page
+// at a time, rather than only through the generic Invoke action dispatch.
+// pkg/sync uses it to drive cross-integration replication.
+type Reader interface {
+    ListRecords(ctx context.Context, resource string, opts ListOptions) (ListResult, error)
+}
+
+// Writer is implemented by connectors that can accept records synced in
+// from another integration. resource names the destination collection
+// (e.g. a Zoho module or an RMS entity) the way Reader's resource does.
+type Writer interface {
+    CreateRecord(ctx context.Context, resource string, record map[string]interface{}) (map[string]interface{}, error)
+}
@@ -0,0 +1,91 @@
+// Package connectors provides a pluggable registry for external service
+// integrations (Zoho CRM, RMS, and future third-party connectors), replacing
+// the bespoke construction signatures each client previously required.
+package connectors
+
+import (
+    "context"
+    "fmt"
+    "sync"
+
+    "github.com/prometheus/client_golang/prometheus"
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/auth"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database"
+)
+
+// Connector is the common surface every integration client implements,
+// regardless of the external service it talks to.
+type Connector interface {
+    // Name returns the service type this connector handles, matching an
+    // Integration.ServiceType value (e.g. models.ServiceTypeZohoCRM).
+    Name() string
+
+    // ConfigSchema returns the connector's configuration schema, used to
+    // validate an Integration.Config payload before it is persisted.
+    ConfigSchema() models.IntegrationConfig
+
+    // HealthCheck verifies the connector can reach the external service.
+    HealthCheck(ctx context.Context) error
+
+    // Sync performs the connector's default data synchronization pass.
+    Sync(ctx context.Context) error
+
+    // Invoke executes an arbitrary connector-defined action, allowing
+    // callers (CLI tools, sync jobs) to drive the connector generically
+    // instead of depending on its concrete type.
+    Invoke(ctx context.Context, action string, params map[string]interface{}) (interface{}, error)
+}
+
+// Factory constructs a Connector for a given integration. It mirrors the
+// signature each connector package's NewXxxClient constructor already uses.
+// db is passed through so connectors can record liveness checkins (see
+// pkg/heartbeat.Checkin) alongside their other database access. It is an
+// *database.AtomicDB, not a bare *gorm.DB, so a CredentialManager rotating
+// the connection pool underneath a long-lived connector is transparent.
+type Factory func(integration *models.Integration, authManager *auth.OAuthManager, db *database.AtomicDB, metrics *prometheus.CounterVec) (Connector, error)
+
+var registry = struct {
+    mu        sync.RWMutex
+    factories map[string]Factory
+}{
+    factories: make(map[string]Factory),
+}
+
+// Register associates serviceType with factory so that New can later
+// construct connectors of that type without the caller needing to know the
+// connector package's concrete constructor. Connector packages call this
+// from an init() function, alongside models.RegisterServiceType.
+func Register(serviceType string, factory Factory) {
+    registry.mu.Lock()
+    defer registry.mu.Unlock()
+    registry.factories[serviceType] = factory
+}
+
+// New constructs a Connector for integration.ServiceType using the
+// registered factory, returning an error if no connector has been
+// registered for that service type.
+func New(integration *models.Integration, authManager *auth.OAuthManager, db *database.AtomicDB, metrics *prometheus.CounterVec) (Connector, error) {
+    registry.mu.RLock()
+    factory, ok := registry.factories[integration.ServiceType]
+    registry.mu.RUnlock()
+
+    if !ok {
+        return nil, fmt.Errorf("no connector registered for service type: %s", integration.ServiceType)
+    }
+
+    return factory(integration, authManager, db, metrics)
+}
+
+// RegisteredTypes returns the service types with a registered factory.
+func RegisteredTypes() []string {
+    registry.mu.RLock()
+    defer registry.mu.RUnlock()
+
+    types := make([]string, 0, len(registry.factories))
+    for t := range registry.factories {
+        types = append(types, t)
+    }
+    return types
+}
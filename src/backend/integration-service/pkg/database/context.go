@@ -0,0 +1,65 @@
+package database
+
+import (
+    "context"
+    "fmt"
+
+    "gorm.io/gorm"
+)
+
+// txContextKey is the unexported key WithTx stores a transaction handle
+// under, so that unrelated packages cannot accidentally collide with it.
+type txContextKey struct{}
+
+// WithTx starts a transaction on db, wires it into ctx so that
+// TxFromContext (and DBFromContext) resolve it, and runs fn with that
+// context. A returned error rolls back the transaction; a panic inside fn
+// is recovered, rolled back, and re-panicked so partial work never commits.
+// This lets a caller orchestrating multiple integration operations (OAuth
+// token refresh, connector reads/writes, status bookkeeping) commit or
+// roll them back as a single unit.
+func WithTx(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) (err error) {
+    tx := db.Begin()
+    if tx.Error != nil {
+        return fmt.Errorf("failed to begin transaction: %w", tx.Error)
+    }
+
+    defer func() {
+        if p := recover(); p != nil {
+            tx.Rollback()
+            panic(p)
+        }
+    }()
+
+    txCtx := context.WithValue(ctx, txContextKey{}, tx)
+
+    if err = fn(txCtx); err != nil {
+        if rbErr := tx.Rollback().Error; rbErr != nil {
+            return fmt.Errorf("%w (rollback also failed: %v)", err, rbErr)
+        }
+        return err
+    }
+
+    if err := tx.Commit().Error; err != nil {
+        return fmt.Errorf("failed to commit transaction: %w", err)
+    }
+    return nil
+}
+
+// TxFromContext returns the transaction handle wired into ctx by WithTx, if
+// any.
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+    tx, ok := ctx.Value(txContextKey{}).(*gorm.DB)
+    return tx, ok
+}
+
+// DBFromContext returns the transaction wired into ctx by WithTx when
+// present, otherwise fallback. Connector clients and the OAuth manager use
+// this so they transparently participate in a caller's transaction without
+// requiring one to exist.
+func DBFromContext(ctx context.Context, fallback *gorm.DB) *gorm.DB {
+    if tx, ok := TxFromContext(ctx); ok {
+        return tx
+    }
+    return fallback
+}
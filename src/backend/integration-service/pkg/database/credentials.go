@@ -0,0 +1,396 @@
+package database
+
+import (
+    "context"
+    "fmt"
+    "os"
+    "sync"
+    "sync/atomic"
+    "time"
+
+    awsconfig "github.com/aws/aws-sdk-go-v2/config"             // v1.18.19
+    rdsauth "github.com/aws/aws-sdk-go-v2/feature/rds/auth"     // v1.3.1
+    vaultapi "github.com/hashicorp/vault/api"                   // v1.9.2
+    "github.com/prometheus/client_golang/prometheus"            // v1.16.0
+    "gorm.io/gorm"                                               // v1.25.0
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/config"
+)
+
+// defaultLeaseRenewBuffer is how long before a lease's expiry
+// CredentialManager renews it, absorbing clock skew and renewal latency so
+// a pool is never caught serving requests on expired credentials.
+const defaultLeaseRenewBuffer = 60 * time.Second
+
+// credentialMetricsInterval is how often Run checks whether a renewal is
+// due and refreshes the lease-age gauge, mirroring heartbeat.Monitor's scan
+// cadence.
+const credentialMetricsInterval = 30 * time.Second
+
+// iamTokenLifetime is how long an AWS RDS IAM authentication token stays
+// valid; AWS fixes this regardless of what's requested.
+const iamTokenLifetime = 15 * time.Minute
+
+// oldPoolDrainGrace is how long renew lets the pool it just replaced finish
+// any in-flight queries before force-closing whatever connections are
+// still open, so a rotation can never leak a full pool's worth of sockets.
+const oldPoolDrainGrace = 30 * time.Second
+
+// Credentials is a username/password pair a CredentialProvider hands back,
+// plus how long it's valid for. A zero LeaseDuration means the credentials
+// don't expire, so CredentialManager never schedules a renewal for them.
+type Credentials struct {
+    Username      string
+    Password      string
+    LeaseDuration time.Duration
+}
+
+// CredentialProvider fetches a fresh set of database credentials. Which
+// implementation is used is selected by config.DatabaseConfig.CredentialSource.
+type CredentialProvider interface {
+    Fetch(ctx context.Context) (Credentials, error)
+}
+
+// staticCredentialProvider always returns cfg's configured username and
+// (already-decrypted) password, with no lease to renew. This is the
+// credential_source every config written before dynamic credentials
+// existed implicitly uses.
+type staticCredentialProvider struct {
+    cfg *config.DatabaseConfig
+}
+
+func (p *staticCredentialProvider) Fetch(ctx context.Context) (Credentials, error) {
+    return Credentials{Username: p.cfg.User, Password: p.cfg.Password}, nil
+}
+
+// vaultCredentialProvider mints short-lived credentials from Vault's
+// database secrets engine (`database/creds/<role>`), the engine this
+// provider models itself on.
+type vaultCredentialProvider struct {
+    client *vaultapi.Client
+    path   string
+}
+
+func newVaultCredentialProvider(cfg *config.DatabaseConfig) (CredentialProvider, error) {
+    address := os.Getenv("VAULT_ADDR")
+    if address == "" {
+        return nil, fmt.Errorf("database: VAULT_ADDR is not set")
+    }
+    token := os.Getenv("VAULT_TOKEN")
+    if token == "" {
+        return nil, fmt.Errorf("database: VAULT_TOKEN is not set")
+    }
+    if cfg.CredentialRole == "" {
+        return nil, fmt.Errorf("database: credential_role is required for credential_source \"vault\"")
+    }
+
+    clientCfg := vaultapi.DefaultConfig()
+    clientCfg.Address = address
+    client, err := vaultapi.NewClient(clientCfg)
+    if err != nil {
+        return nil, fmt.Errorf("database: failed to create vault client: %w", err)
+    }
+    client.SetToken(token)
+
+    return &vaultCredentialProvider{
+        client: client,
+        path:   "database/creds/" + cfg.CredentialRole,
+    }, nil
+}
+
+func (p *vaultCredentialProvider) Fetch(ctx context.Context) (Credentials, error) {
+    secret, err := p.client.Logical().ReadWithContext(ctx, p.path)
+    if err != nil {
+        return Credentials{}, fmt.Errorf("database: vault lease request failed: %w", err)
+    }
+    if secret == nil || secret.Data == nil {
+        return Credentials{}, fmt.Errorf("database: vault path %s returned no data", p.path)
+    }
+
+    username, _ := secret.Data["username"].(string)
+    password, _ := secret.Data["password"].(string)
+    if username == "" || password == "" {
+        return Credentials{}, fmt.Errorf("database: vault path %s did not return a username/password", p.path)
+    }
+
+    return Credentials{
+        Username:      username,
+        Password:      password,
+        LeaseDuration: time.Duration(secret.LeaseDuration) * time.Second,
+    }, nil
+}
+
+// iamCredentialProvider generates AWS RDS IAM authentication tokens, which
+// RDS accepts as a password in place of the user's real one for up to
+// iamTokenLifetime.
+type iamCredentialProvider struct {
+    cfg *config.DatabaseConfig
+}
+
+func newIAMCredentialProvider(cfg *config.DatabaseConfig) (CredentialProvider, error) {
+    if cfg.CredentialRegion == "" {
+        return nil, fmt.Errorf("database: credential_region is required for credential_source \"iam\"")
+    }
+    return &iamCredentialProvider{cfg: cfg}, nil
+}
+
+func (p *iamCredentialProvider) Fetch(ctx context.Context) (Credentials, error) {
+    awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(p.cfg.CredentialRegion))
+    if err != nil {
+        return Credentials{}, fmt.Errorf("database: failed to load AWS config: %w", err)
+    }
+
+    endpoint := fmt.Sprintf("%s:%d", p.cfg.Host, p.cfg.Port)
+    token, err := rdsauth.BuildAuthToken(ctx, endpoint, p.cfg.CredentialRegion, p.cfg.User, awsCfg.Credentials)
+    if err != nil {
+        return Credentials{}, fmt.Errorf("database: failed to build RDS IAM auth token: %w", err)
+    }
+
+    return Credentials{
+        Username:      p.cfg.User,
+        Password:      token,
+        LeaseDuration: iamTokenLifetime,
+    }, nil
+}
+
+// newCredentialProvider selects a CredentialProvider for cfg.CredentialSource.
+func newCredentialProvider(cfg *config.DatabaseConfig) (CredentialProvider, error) {
+    switch cfg.CredentialSource {
+    case "", "static":
+        return &staticCredentialProvider{cfg: cfg}, nil
+    case "vault":
+        return newVaultCredentialProvider(cfg)
+    case "iam":
+        return newIAMCredentialProvider(cfg)
+    default:
+        return nil, fmt.Errorf("database: unknown credential_source %q", cfg.CredentialSource)
+    }
+}
+
+// AtomicDB holds a *gorm.DB that can be swapped out from under callers, so a
+// CredentialManager can rotate the underlying connection pool without
+// downstream code (OAuthManager, heartbeat.Monitor, connector clients)
+// needing to know a rotation happened. Callers should call Get() right
+// before each use rather than caching its result, the same way
+// database.DBFromContext is resolved fresh on every call.
+type AtomicDB struct {
+    ptr atomic.Value // *gorm.DB
+}
+
+// NewStaticAtomicDB wraps db in an AtomicDB that never rotates, for callers
+// (tests, one-shot CLIs) that have no CredentialManager of their own.
+func NewStaticAtomicDB(db *gorm.DB) *AtomicDB {
+    a := &AtomicDB{}
+    a.ptr.Store(db)
+    return a
+}
+
+// Get returns the current connection pool.
+func (a *AtomicDB) Get() *gorm.DB {
+    return a.ptr.Load().(*gorm.DB)
+}
+
+// swap installs db as current and returns the pool it replaced.
+func (a *AtomicDB) swap(db *gorm.DB) *gorm.DB {
+    old := a.ptr.Swap(db)
+    if old == nil {
+        return nil
+    }
+    return old.(*gorm.DB)
+}
+
+// credentialMetrics tracks CredentialManager rotation health.
+type credentialMetrics struct {
+    leaseAgeSeconds prometheus.Gauge
+    renewalFailures prometheus.Counter
+    swapLatency     prometheus.Histogram
+}
+
+func newCredentialMetrics() *credentialMetrics {
+    return &credentialMetrics{
+        leaseAgeSeconds: prometheus.NewGauge(prometheus.GaugeOpts{
+            Name: "database_credential_lease_age_seconds",
+            Help: "Seconds since the current database credential lease was issued.",
+        }),
+        renewalFailures: prometheus.NewCounter(prometheus.CounterOpts{
+            Name: "database_credential_renewal_failures_total",
+            Help: "Number of times fetching or swapping in renewed database credentials failed.",
+        }),
+        swapLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+            Name:    "database_credential_swap_latency_seconds",
+            Help:    "Time taken to fetch renewed credentials, open a new connection pool, and swap it in.",
+            Buckets: prometheus.DefBuckets,
+        }),
+    }
+}
+
+func registerCredentialMetrics(m *credentialMetrics) error {
+    for _, c := range []prometheus.Collector{m.leaseAgeSeconds, m.renewalFailures, m.swapLatency} {
+        if err := prometheus.Register(c); err != nil {
+            if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+                return fmt.Errorf("database: failed to register credential metrics: %w", err)
+            }
+        }
+    }
+    return nil
+}
+
+// CredentialManager fetches database credentials from the provider
+// selected by cfg.CredentialSource and keeps an AtomicDB pointed at a live
+// connection pool, renewing it before each lease expires.
+type CredentialManager struct {
+    cfg         *config.DatabaseConfig
+    provider    CredentialProvider
+    db          *AtomicDB
+    renewBuffer time.Duration
+    metrics     *credentialMetrics
+
+    mu            sync.Mutex // guards leaseIssued/leaseDuration
+    leaseIssued   time.Time
+    leaseDuration time.Duration
+}
+
+// NewCredentialManager builds a CredentialManager and opens its first
+// connection pool, migrating the schema exactly once (renewals reuse the
+// existing schema). cfg.CredentialSource selects the provider; "" defaults
+// to "static", matching a config file written before dynamic credentials
+// existed.
+func NewCredentialManager(cfg *config.DatabaseConfig) (*CredentialManager, error) {
+    provider, err := newCredentialProvider(cfg)
+    if err != nil {
+        return nil, err
+    }
+
+    renewBuffer := cfg.LeaseRenewBuffer
+    if renewBuffer <= 0 {
+        renewBuffer = defaultLeaseRenewBuffer
+    }
+
+    m := &CredentialManager{
+        cfg:         cfg,
+        provider:    provider,
+        renewBuffer: renewBuffer,
+        metrics:     newCredentialMetrics(),
+    }
+    if err := registerCredentialMetrics(m.metrics); err != nil {
+        return nil, err
+    }
+
+    creds, err := provider.Fetch(context.Background())
+    if err != nil {
+        return nil, fmt.Errorf("database: failed to fetch initial credentials: %w", err)
+    }
+
+    db, err := openPostgres(cfg, creds.Username, creds.Password)
+    if err != nil {
+        return nil, err
+    }
+    if err := initializeSchema(db); err != nil {
+        return nil, fmt.Errorf("failed to initialize schema: %w", err)
+    }
+
+    m.db = NewStaticAtomicDB(db)
+    m.leaseIssued = time.Now()
+    m.leaseDuration = creds.LeaseDuration
+    m.metrics.leaseAgeSeconds.Set(0)
+
+    return m, nil
+}
+
+// DB returns the AtomicDB callers should thread through instead of holding
+// a *gorm.DB directly, so they pick up rotated credentials transparently.
+func (m *CredentialManager) DB() *AtomicDB {
+    return m.db
+}
+
+// Run renews credentials before each lease expires until ctx is canceled.
+// Static credentials (LeaseDuration zero) never expire, so Run just waits
+// for cancellation without scheduling anything.
+func (m *CredentialManager) Run(ctx context.Context) {
+    if m.currentLeaseDuration() <= 0 {
+        <-ctx.Done()
+        return
+    }
+
+    ticker := time.NewTicker(credentialMetricsInterval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            m.metrics.leaseAgeSeconds.Set(time.Since(m.currentLeaseIssued()).Seconds())
+            if m.dueForRenewal() {
+                if err := m.renew(ctx); err != nil {
+                    m.metrics.renewalFailures.Inc()
+                }
+            }
+        }
+    }
+}
+
+func (m *CredentialManager) currentLeaseDuration() time.Duration {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    return m.leaseDuration
+}
+
+func (m *CredentialManager) currentLeaseIssued() time.Time {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    return m.leaseIssued
+}
+
+func (m *CredentialManager) dueForRenewal() bool {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    if m.leaseDuration <= 0 {
+        return false
+    }
+    return time.Since(m.leaseIssued) >= m.leaseDuration-m.renewBuffer
+}
+
+// renew fetches a new credential lease, opens a fresh connection pool
+// against it, and atomically swaps it into m.db, then drains the old pool:
+// it stops letting connections sit idle (so each closes as soon as the
+// query using it finishes) and, as a backstop, force-closes whatever is
+// left after oldPoolDrainGrace, rather than leaking the old pool's
+// connections indefinitely.
+func (m *CredentialManager) renew(ctx context.Context) error {
+    start := time.Now()
+
+    creds, err := m.provider.Fetch(ctx)
+    if err != nil {
+        return fmt.Errorf("database: failed to fetch renewed credentials: %w", err)
+    }
+
+    newDB, err := openPostgres(m.cfg, creds.Username, creds.Password)
+    if err != nil {
+        return fmt.Errorf("database: failed to open renewed connection pool: %w", err)
+    }
+
+    oldDB := m.db.swap(newDB)
+
+    m.mu.Lock()
+    m.leaseIssued = time.Now()
+    m.leaseDuration = creds.LeaseDuration
+    m.mu.Unlock()
+
+    m.metrics.leaseAgeSeconds.Set(0)
+    m.metrics.swapLatency.Observe(time.Since(start).Seconds())
+
+    if oldDB != nil {
+        if sqlDB, err := oldDB.DB(); err == nil {
+            // Close each connection as soon as it's returned idle instead
+            // of keeping it around, and force-close anything still open
+            // once in-flight queries have had oldPoolDrainGrace to finish.
+            sqlDB.SetMaxIdleConns(0)
+            time.AfterFunc(oldPoolDrainGrace, func() {
+                sqlDB.Close()
+            })
+        }
+    }
+
+    return nil
+}
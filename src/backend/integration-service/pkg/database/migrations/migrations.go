@@ -0,0 +1,100 @@
+// Package migrations implements a minimal versioned SQL migration runner
+// for the integration service's Postgres schema, replacing the
+// db.AutoMigrate calls pkg/database and pkg/webhooks used to rely on.
+package migrations
+
+import (
+    "crypto/sha256"
+    "embed"
+    "encoding/hex"
+    "fmt"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+// Migration is one versioned schema change, made up of an up script and,
+// optionally, a down script to reverse it. Checksum covers both scripts,
+// so drift in either an already-applied migration's up or down file is
+// caught by Runner.Up before anything else runs.
+type Migration struct {
+    Version  int
+    Name     string
+    UpSQL    string
+    DownSQL  string
+    Checksum string
+}
+
+// Load reads every migration embedded under sql/, pairing each
+// <version>_<name>.up.sql with its <version>_<name>.down.sql, and returns
+// them sorted by version ascending.
+func Load() ([]Migration, error) {
+    entries, err := sqlFiles.ReadDir("sql")
+    if err != nil {
+        return nil, fmt.Errorf("migrations: read embedded sql dir: %w", err)
+    }
+
+    byVersion := map[int]*Migration{}
+    for _, entry := range entries {
+        name := entry.Name()
+        version, rest, ok := splitVersion(name)
+        if !ok {
+            return nil, fmt.Errorf("migrations: %s does not start with a numeric version", name)
+        }
+
+        data, err := sqlFiles.ReadFile("sql/" + name)
+        if err != nil {
+            return nil, fmt.Errorf("migrations: read %s: %w", name, err)
+        }
+
+        m := byVersion[version]
+        if m == nil {
+            m = &Migration{Version: version}
+            byVersion[version] = m
+        }
+
+        switch {
+        case strings.HasSuffix(rest, ".up.sql"):
+            m.Name = strings.TrimSuffix(rest, ".up.sql")
+            m.UpSQL = string(data)
+        case strings.HasSuffix(rest, ".down.sql"):
+            m.DownSQL = string(data)
+        default:
+            return nil, fmt.Errorf("migrations: %s does not end in .up.sql or .down.sql", name)
+        }
+    }
+
+    migrations := make([]Migration, 0, len(byVersion))
+    for _, m := range byVersion {
+        if m.UpSQL == "" {
+            return nil, fmt.Errorf("migrations: version %d is missing its .up.sql", m.Version)
+        }
+        m.Checksum = checksum(m.UpSQL + m.DownSQL)
+        migrations = append(migrations, *m)
+    }
+    sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+    return migrations, nil
+}
+
+// splitVersion splits a migration filename like "0003_add_widgets.up.sql"
+// into its version number and the remainder ("add_widgets.up.sql").
+func splitVersion(filename string) (version int, rest string, ok bool) {
+    underscore := strings.IndexByte(filename, '_')
+    if underscore < 0 {
+        return 0, "", false
+    }
+    v, err := strconv.Atoi(filename[:underscore])
+    if err != nil {
+        return 0, "", false
+    }
+    return v, filename[underscore+1:], true
+}
+
+func checksum(s string) string {
+    sum := sha256.Sum256([]byte(s))
+    return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,247 @@
+package migrations
+
+import (
+    "context"
+    "fmt"
+    "os"
+    "os/user"
+    "time"
+
+    "gorm.io/gorm" // v1.25.0
+)
+
+// advisoryLockKey is an arbitrary, fixed int64 passed to pg_advisory_lock
+// so every replica applying migrations against the same database
+// serializes against the same lock, regardless of which migration it's
+// currently trying to apply. It must stay constant across every
+// deployment of this service.
+const advisoryLockKey = 72177304
+
+// migrationsTable tracks which migrations have been applied.
+const migrationsTable = "schema_migrations"
+
+// AppliedMigration is one row of schema_migrations.
+type AppliedMigration struct {
+    Version   int       `gorm:"primaryKey;column:version"`
+    Checksum  string    `gorm:"column:checksum;not null"`
+    AppliedAt time.Time `gorm:"column:applied_at;not null"`
+    AppliedBy string    `gorm:"column:applied_by;not null"`
+}
+
+// TableName specifies the database table name for AppliedMigration.
+func (AppliedMigration) TableName() string {
+    return migrationsTable
+}
+
+// Status describes one embedded migration's applied state, for
+// `migrate status`.
+type Status struct {
+    Version int
+    Name    string
+    Applied bool
+}
+
+// Runner applies and inspects migrations against db.
+type Runner struct {
+    db         *gorm.DB
+    migrations []Migration
+}
+
+// NewRunner loads the embedded migrations and returns a Runner for db.
+func NewRunner(db *gorm.DB) (*Runner, error) {
+    migrations, err := Load()
+    if err != nil {
+        return nil, err
+    }
+    return &Runner{db: db, migrations: migrations}, nil
+}
+
+// Up applies every pending migration in version order, each inside its
+// own transaction, after acquiring a Postgres advisory lock so concurrent
+// replicas starting up at once serialize rather than racing to apply the
+// same migration twice. Before applying anything it verifies the
+// checksum of every already-applied migration against the embedded copy,
+// failing loudly on drift rather than silently reapplying or ignoring it.
+func (r *Runner) Up(ctx context.Context) error {
+    return r.withLock(ctx, func(db *gorm.DB) error {
+        applied, err := r.appliedByVersion(ctx, db)
+        if err != nil {
+            return err
+        }
+
+        for _, m := range r.migrations {
+            if existing, ok := applied[m.Version]; ok && existing.Checksum != m.Checksum {
+                return fmt.Errorf("migrations: version %d has drifted since it was applied: stored checksum %s, embedded checksum %s", m.Version, existing.Checksum, m.Checksum)
+            }
+        }
+        for _, m := range r.migrations {
+            if _, ok := applied[m.Version]; ok {
+                continue
+            }
+            if err := r.apply(ctx, db, m); err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+}
+
+// Down rolls back the n most recently applied migrations, most recent
+// first, each inside its own transaction.
+func (r *Runner) Down(ctx context.Context, n int) error {
+    return r.withLock(ctx, func(db *gorm.DB) error {
+        var rows []AppliedMigration
+        if err := db.WithContext(ctx).Order("version DESC").Limit(n).Find(&rows).Error; err != nil {
+            return fmt.Errorf("migrations: list applied: %w", err)
+        }
+
+        byVersion := make(map[int]Migration, len(r.migrations))
+        for _, m := range r.migrations {
+            byVersion[m.Version] = m
+        }
+
+        for _, row := range rows {
+            m, ok := byVersion[row.Version]
+            if !ok {
+                return fmt.Errorf("migrations: version %d is applied but no longer embedded; can't compute its down script", row.Version)
+            }
+            if m.DownSQL == "" {
+                return fmt.Errorf("migrations: version %d has no down script", row.Version)
+            }
+            if err := r.revert(ctx, db, m); err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+}
+
+// Status reports, for every embedded migration, whether it has been
+// applied.
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+    var statuses []Status
+    err := r.withLock(ctx, func(db *gorm.DB) error {
+        applied, err := r.appliedByVersion(ctx, db)
+        if err != nil {
+            return err
+        }
+
+        statuses = make([]Status, 0, len(r.migrations))
+        for _, m := range r.migrations {
+            _, ok := applied[m.Version]
+            statuses = append(statuses, Status{Version: m.Version, Name: m.Name, Applied: ok})
+        }
+        return nil
+    })
+    return statuses, err
+}
+
+// Force records version as applied, with its current embedded checksum,
+// without running its up script. It exists for recovering from a
+// migration that was applied by hand, or one whose up script partially
+// failed outside a transaction (e.g. a long-running DDL statement
+// interrupted by an operator) and needs schema_migrations to be told it's
+// actually in place before Up is tried again.
+func (r *Runner) Force(ctx context.Context, version int) error {
+    return r.withLock(ctx, func(db *gorm.DB) error {
+        var target *Migration
+        for i := range r.migrations {
+            if r.migrations[i].Version == version {
+                target = &r.migrations[i]
+                break
+            }
+        }
+        if target == nil {
+            return fmt.Errorf("migrations: version %d is not an embedded migration", version)
+        }
+
+        return db.WithContext(ctx).Save(&AppliedMigration{
+            Version:   version,
+            Checksum:  target.Checksum,
+            AppliedAt: time.Now(),
+            AppliedBy: appliedBy(),
+        }).Error
+    })
+}
+
+// withLock pins a single connection out of r.db's pool for the duration of
+// fn via gorm's Connection, then acquires the session-level advisory lock
+// on that same connection. pg_advisory_lock/pg_advisory_unlock are
+// session-scoped in Postgres, so running the lock, fn's body, and the
+// unlock as independent pooled statements (as earlier versions of this did)
+// would let GORM hand each one a different connection out of the pool,
+// defeating the lock entirely; pinning one connection here is what makes it
+// actually serialize concurrent replicas. Postgres releases the lock
+// automatically if the connection drops, so a crashed runner can never
+// leave migrations permanently stuck.
+func (r *Runner) withLock(ctx context.Context, fn func(db *gorm.DB) error) error {
+    return r.db.WithContext(ctx).Connection(func(tx *gorm.DB) error {
+        if err := tx.Exec("SELECT pg_advisory_lock(?)", advisoryLockKey).Error; err != nil {
+            return fmt.Errorf("migrations: failed to acquire advisory lock: %w", err)
+        }
+        defer tx.Exec("SELECT pg_advisory_unlock(?)", advisoryLockKey)
+
+        if err := r.ensureMigrationsTable(ctx, tx); err != nil {
+            return err
+        }
+
+        return fn(tx)
+    })
+}
+
+// ensureMigrationsTable creates schema_migrations with a raw DDL statement
+// rather than db.AutoMigrate, so this package's own bookkeeping table
+// doesn't depend on the GORM migration behavior it exists to replace.
+func (r *Runner) ensureMigrationsTable(ctx context.Context, db *gorm.DB) error {
+    return db.WithContext(ctx).Exec(`
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            version    integer PRIMARY KEY,
+            checksum   varchar(64) NOT NULL,
+            applied_at timestamptz NOT NULL,
+            applied_by varchar(255) NOT NULL
+        )
+    `).Error
+}
+
+func (r *Runner) apply(ctx context.Context, db *gorm.DB, m Migration) error {
+    return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        if err := tx.Exec(m.UpSQL).Error; err != nil {
+            return fmt.Errorf("migrations: applying version %d: %w", m.Version, err)
+        }
+        return tx.Exec(
+            "INSERT INTO schema_migrations (version, checksum, applied_at, applied_by) VALUES (?, ?, ?, ?)",
+            m.Version, m.Checksum, time.Now(), appliedBy(),
+        ).Error
+    })
+}
+
+func (r *Runner) revert(ctx context.Context, db *gorm.DB, m Migration) error {
+    return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        if err := tx.Exec(m.DownSQL).Error; err != nil {
+            return fmt.Errorf("migrations: reverting version %d: %w", m.Version, err)
+        }
+        return tx.Exec("DELETE FROM schema_migrations WHERE version = ?", m.Version).Error
+    })
+}
+
+func (r *Runner) appliedByVersion(ctx context.Context, db *gorm.DB) (map[int]AppliedMigration, error) {
+    var rows []AppliedMigration
+    if err := db.WithContext(ctx).Find(&rows).Error; err != nil {
+        return nil, fmt.Errorf("migrations: list applied: %w", err)
+    }
+    out := make(map[int]AppliedMigration, len(rows))
+    for _, row := range rows {
+        out[row.Version] = row
+    }
+    return out, nil
+}
+
+// appliedBy identifies who ran a migration, for schema_migrations.applied_by.
+func appliedBy() string {
+    host, _ := os.Hostname()
+    u, err := user.Current()
+    if err != nil {
+        return host
+    }
+    return fmt.Sprintf("%s@%s", u.Username, host)
+}
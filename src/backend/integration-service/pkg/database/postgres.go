@@ -5,7 +5,6 @@ package database
 
 import (
     "context"
-    "errors"
     "fmt"
     "time"
 
@@ -13,7 +12,8 @@ import (
     "gorm.io/gorm"           // v1.25.0
 
     "github.com/yourdomain/agent-ai-platform/integration-service/config"
-    "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/audit"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database/migrations"
 )
 
 // Global connection pool settings for optimal performance
@@ -37,6 +37,35 @@ type dbInstance struct {
 // NewPostgresDB creates and configures a new PostgreSQL database connection
 // with advanced features including connection pooling, health checks, and retry logic.
 func NewPostgresDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
+    db, err := openPostgres(cfg, cfg.User, cfg.Password)
+    if err != nil {
+        return nil, err
+    }
+
+    // Initialize schema and verify tables
+    if err := initializeSchema(db); err != nil {
+        return nil, fmt.Errorf("failed to initialize schema: %w", err)
+    }
+
+    return db, nil
+}
+
+// NewUnmigratedPostgresDB opens a connection pool against cfg exactly like
+// NewPostgresDB, but skips applying pending migrations, since
+// cmd/agentx-migrate drives a migrations.Runner over the pool itself and
+// an automatic Up here would fight with `migrate down`/`migrate status`.
+func NewUnmigratedPostgresDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
+    return openPostgres(cfg, cfg.User, cfg.Password)
+}
+
+// openPostgres opens a connection pool against cfg using the given
+// username/password rather than cfg.User/cfg.Password directly, so a
+// CredentialManager (see credentials.go) can open a fresh pool against
+// dynamically-fetched credentials without duplicating the retry/pool/ping
+// logic. It does not run schema migrations: callers minting a replacement
+// pool on credential rotation intentionally skip re-migrating a schema that
+// is already in place.
+func openPostgres(cfg *config.DatabaseConfig, username, password string) (*gorm.DB, error) {
     var db *gorm.DB
     var err error
 
@@ -45,6 +74,11 @@ func NewPostgresDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
         PrepareStmt:            true, // Enable prepared statement cache
         SkipDefaultTransaction: true, // Optimize performance for non-transactional operations
         Logger:                 newDBLogger(),
+        // TranslateError turns driver-specific constraint violations (e.g.
+        // a unique-key conflict) into gorm's portable sentinel errors, such
+        // as gorm.ErrDuplicatedKey, instead of leaving callers to inspect
+        // the raw *pgconn.PgError themselves.
+        TranslateError: true,
     }
 
     // Implement retry logic for connection establishment
@@ -54,7 +88,7 @@ func NewPostgresDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 
         // Establish connection with context timeout
         db, err = gorm.Open(postgres.New(postgres.Config{
-            DSN: buildDSN(cfg),
+            DSN: buildDSN(cfg, username, password),
             PreferSimpleProtocol: true, // Better performance for prepared statements
         }), gormConfig)
 
@@ -87,11 +121,6 @@ func NewPostgresDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
         return nil, fmt.Errorf("failed to ping database: %w", err)
     }
 
-    // Initialize schema and verify tables
-    if err := initializeSchema(db); err != nil {
-        return nil, fmt.Errorf("failed to initialize schema: %w", err)
-    }
-
     return db, nil
 }
 
@@ -114,9 +143,16 @@ func Close(db *gorm.DB) error {
     return nil
 }
 
-// WithTransaction executes database operations within a transaction
-// with advanced monitoring and safety features
-func WithTransaction(db *gorm.DB, fn func(*gorm.DB) error) error {
+// WithTransaction executes database operations within a transaction with
+// advanced monitoring and safety features. It takes an *AtomicDB, not a
+// *gorm.DB, resolving the current pool with Get() at the moment the
+// transaction begins so an in-progress credential rotation (see
+// credentials.go) can never start a transaction against a pool that's
+// already been swapped out. Its commit/rollback outcome is recorded to
+// pkg/audit, a no-op unless SecurityConfig.EnableAudit is set.
+func WithTransaction(adb *AtomicDB, fn func(*gorm.DB) error) error {
+    db := adb.Get()
+
     // Start transaction with timeout
     tx := db.Begin()
     if tx.Error != nil {
@@ -133,44 +169,48 @@ func WithTransaction(db *gorm.DB, fn func(*gorm.DB) error) error {
     if err := fn(tx); err != nil {
         // Rollback on error
         if rbErr := tx.Rollback().Error; rbErr != nil {
+            audit.Record(audit.Event{Actor: "system", Action: "db-transaction", Outcome: "rollback-failed", Details: map[string]interface{}{"error": err.Error(), "rollback_error": rbErr.Error()}})
             return fmt.Errorf("transaction failed and rollback failed: %v (rollback error: %v)", err, rbErr)
         }
+        audit.Record(audit.Event{Actor: "system", Action: "db-transaction", Outcome: "rollback", Details: map[string]interface{}{"error": err.Error()}})
         return err
     }
 
     // Commit transaction
     if err := tx.Commit().Error; err != nil {
+        audit.Record(audit.Event{Actor: "system", Action: "db-transaction", Outcome: "commit-failed", Details: map[string]interface{}{"error": err.Error()}})
         return fmt.Errorf("failed to commit transaction: %w", err)
     }
 
+    audit.Record(audit.Event{Actor: "system", Action: "db-transaction", Outcome: "commit"})
     return nil
 }
 
 // buildDSN constructs the database connection string with proper escaping
-func buildDSN(cfg *config.DatabaseConfig) string {
+func buildDSN(cfg *config.DatabaseConfig, username, password string) string {
     return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
         cfg.Host,
         cfg.Port,
-        cfg.User,
-        cfg.Password,
+        username,
+        password,
         cfg.Name,
         cfg.SSLMode,
     )
 }
 
-// initializeSchema verifies and initializes the database schema
+// initializeSchema applies every pending schema migration (see
+// pkg/database/migrations) against db. The runner acquires a Postgres
+// advisory lock first, so concurrent replicas starting up at once
+// serialize rather than racing to apply the same migration twice; this
+// replaces the db.AutoMigrate call this function used to make, which had
+// no such protection and couldn't detect an already-applied migration
+// that had drifted from what the code expected.
 func initializeSchema(db *gorm.DB) error {
-    // Auto-migrate the integration model
-    if err := db.AutoMigrate(&models.Integration{}); err != nil {
-        return fmt.Errorf("failed to migrate integration model: %w", err)
-    }
-
-    // Verify table existence
-    if !db.Migrator().HasTable(&models.Integration{}) {
-        return errors.New("integration table not created after migration")
+    runner, err := migrations.NewRunner(db)
+    if err != nil {
+        return fmt.Errorf("failed to load migrations: %w", err)
     }
-
-    return nil
+    return runner.Up(context.Background())
 }
 
 // newDBLogger creates a new GORM logger with custom configuration
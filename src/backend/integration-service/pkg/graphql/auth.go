@@ -0,0 +1,72 @@
+package graphql
+
+import (
+    "context"
+    "crypto/subtle"
+    "fmt"
+
+    "github.com/google/uuid" // v1.3.0
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/auth"
+)
+
+// bearerTokenKey is the context key serveQuery/serveSubscription stash the
+// caller's bearer token under, before any resolver runs.
+type bearerTokenKey struct{}
+
+// tenantIDKey is the context key serveQuery/serveSubscription stash the
+// caller's claimed tenant ID under, before any resolver runs.
+type tenantIDKey struct{}
+
+// contextWithBearerToken wires token into ctx for authorizeIntegrationAccess
+// to later read back.
+func contextWithBearerToken(ctx context.Context, token string) context.Context {
+    return context.WithValue(ctx, bearerTokenKey{}, token)
+}
+
+func bearerTokenFromContext(ctx context.Context) (string, bool) {
+    token, ok := ctx.Value(bearerTokenKey{}).(string)
+    return token, ok && token != ""
+}
+
+// contextWithTenantID wires tenantID into ctx for authorizeIntegrationAccess
+// to later read back.
+func contextWithTenantID(ctx context.Context, tenantID string) context.Context {
+    return context.WithValue(ctx, tenantIDKey{}, tenantID)
+}
+
+func tenantIDFromContext(ctx context.Context) (string, bool) {
+    tenantID, ok := ctx.Value(tenantIDKey{}).(string)
+    return tenantID, ok && tenantID != ""
+}
+
+// authorizeIntegrationAccess gates a field resolver that exposes
+// integration-sensitive data (Integration.config and Integration.records in
+// particular, which can surface connector credentials and live customer
+// data) behind the same OAuth access token auth.OAuthManager already
+// issues outbound requests with: a caller must present that integration's
+// own current access token to read its fields, so a leaked GraphQL
+// endpoint can't be used to read every integration's data at once. It
+// resolves that token through GetTokenForTenant rather than GetToken, so a
+// caller claiming tenant A can never be handed tenant B's token by
+// supplying B's integration ID.
+func authorizeIntegrationAccess(ctx context.Context, authManager *auth.OAuthManager, integrationID uuid.UUID) error {
+    token, ok := bearerTokenFromContext(ctx)
+    if !ok {
+        return fmt.Errorf("graphql: request is missing a bearer token")
+    }
+    tenantID, ok := tenantIDFromContext(ctx)
+    if !ok {
+        return fmt.Errorf("graphql: request is missing a tenant ID")
+    }
+
+    stored, err := authManager.GetTokenForTenant(ctx, tenantID, integrationID)
+    if err != nil {
+        return fmt.Errorf("graphql: resolving integration %s's token: %w", integrationID, err)
+    }
+
+    if subtle.ConstantTimeCompare([]byte(token), []byte(stored.AccessToken)) != 1 {
+        return fmt.Errorf("graphql: bearer token does not authorize access to integration %s", integrationID)
+    }
+    return nil
+}
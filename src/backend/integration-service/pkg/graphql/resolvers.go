@@ -0,0 +1,161 @@
+package graphql
+
+import (
+    "fmt"
+    "strconv"
+    "time"
+
+    "github.com/google/uuid"                         // v1.3.0
+    "github.com/graphql-go/graphql"                  // v0.8.1
+    "github.com/prometheus/client_golang/prometheus" // v1.16.0
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/auth"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/connectors"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database"
+)
+
+// defaultPageSize bounds how many rows/records a connection returns when
+// the caller does not supply `first`.
+const defaultPageSize = 20
+
+// Resolver holds the dependencies every GraphQL field resolver needs: the
+// database to read models.Integration from, the OAuthManager both
+// connector construction and authorizeIntegrationAccess depend on, and the
+// metrics vector connectors.New requires for the connector it builds.
+type Resolver struct {
+    db          *database.AtomicDB
+    authManager *auth.OAuthManager
+    metrics     *prometheus.CounterVec
+}
+
+// NewResolver builds a Resolver. db, authManager, and metrics are the same
+// dependencies main.go already threads through to pkg/connectors elsewhere.
+func NewResolver(db *database.AtomicDB, authManager *auth.OAuthManager, metrics *prometheus.CounterVec) *Resolver {
+    return &Resolver{db: db, authManager: authManager, metrics: metrics}
+}
+
+func (r *Resolver) resolveIntegration(p graphql.ResolveParams) (interface{}, error) {
+    idStr, _ := p.Args["id"].(string)
+    id, err := uuid.Parse(idStr)
+    if err != nil {
+        return nil, fmt.Errorf("graphql: invalid id %q: %w", idStr, err)
+    }
+
+    var integration models.Integration
+    if err := r.db.Get().WithContext(p.Context).First(&integration, "id = ?", id).Error; err != nil {
+        return nil, fmt.Errorf("graphql: integration %s: %w", id, err)
+    }
+    return &integration, nil
+}
+
+// resolveIntegrations paginates integrations by offset, encoded as a
+// decimal string cursor, matching the cursor convention
+// internal/services/rms's connector already uses for the same reason:
+// Postgres has no stable row handle to hand back as an opaque pointer.
+func (r *Resolver) resolveIntegrations(p graphql.ResolveParams) (interface{}, error) {
+    first := defaultPageSize
+    if v, ok := p.Args["first"].(int); ok && v > 0 {
+        first = v
+    }
+
+    offset := 0
+    if after, ok := p.Args["after"].(string); ok && after != "" {
+        v, err := strconv.Atoi(after)
+        if err != nil {
+            return nil, fmt.Errorf("graphql: invalid after cursor %q: %w", after, err)
+        }
+        offset = v
+    }
+
+    query := r.db.Get().WithContext(p.Context).Model(&models.Integration{})
+    if serviceType, ok := p.Args["serviceType"].(string); ok && serviceType != "" {
+        query = query.Where("service_type = ?", serviceType)
+    }
+    if status, ok := p.Args["status"].(string); ok && status != "" {
+        query = query.Where("status = ?", status)
+    }
+
+    // Fetch one row past the page so hasNextPage doesn't require a
+    // separate COUNT query.
+    var rows []models.Integration
+    if err := query.Order("created_at, id").Offset(offset).Limit(first + 1).Find(&rows).Error; err != nil {
+        return nil, fmt.Errorf("graphql: list integrations: %w", err)
+    }
+
+    hasNext := len(rows) > first
+    if hasNext {
+        rows = rows[:first]
+    }
+
+    edges := make([]integrationEdge, len(rows))
+    for i := range rows {
+        edges[i] = integrationEdge{cursor: strconv.Itoa(offset + i + 1), node: &rows[i]}
+    }
+
+    endCursor := ""
+    if len(edges) > 0 {
+        endCursor = edges[len(edges)-1].cursor
+    }
+
+    return &integrationConnection{
+        edges:    edges,
+        pageInfo: pageInfo{endCursor: endCursor, hasNext: hasNext},
+    }, nil
+}
+
+// resolveRecords backs Integration.records, fetching one page of the
+// connector's records through connectors.Reader.ListRecords. Every edge's
+// cursor is the page's NextCursor rather than a per-record one: Reader
+// only hands back a cursor for resuming the whole page, not a handle per
+// record, the same limitation pkg/sync already lives with.
+func (r *Resolver) resolveRecords(p graphql.ResolveParams) (interface{}, error) {
+    integration, ok := p.Source.(*models.Integration)
+    if !ok {
+        return nil, fmt.Errorf("graphql: records resolver used on a non-Integration source")
+    }
+
+    if err := authorizeIntegrationAccess(p.Context, r.authManager, integration.ID); err != nil {
+        return nil, err
+    }
+
+    resource, _ := p.Args["resource"].(string)
+    if resource == "" {
+        return nil, fmt.Errorf("graphql: records requires a resource argument")
+    }
+
+    connector, err := connectors.New(integration, r.authManager, r.db, r.metrics)
+    if err != nil {
+        return nil, fmt.Errorf("graphql: building connector: %w", err)
+    }
+    reader, ok := connector.(connectors.Reader)
+    if !ok {
+        return nil, fmt.Errorf("graphql: %s connector does not support listing records", integration.ServiceType)
+    }
+
+    opts := connectors.ListOptions{Limit: defaultPageSize}
+    if first, ok := p.Args["first"].(int); ok && first > 0 {
+        opts.Limit = first
+    }
+    if after, ok := p.Args["after"].(string); ok {
+        opts.Cursor = after
+    }
+    if since, ok := p.Args["since"].(time.Time); ok {
+        opts.Since = since
+    }
+
+    result, err := reader.ListRecords(p.Context, resource, opts)
+    if err != nil {
+        return nil, fmt.Errorf("graphql: listing %s records: %w", resource, err)
+    }
+
+    edges := make([]recordEdge, len(result.Records))
+    for i, record := range result.Records {
+        edges[i] = recordEdge{cursor: result.NextCursor, node: record}
+    }
+
+    return &recordConnection{
+        edges:    edges,
+        pageInfo: pageInfo{endCursor: result.NextCursor, hasNext: !result.Done},
+    }, nil
+}
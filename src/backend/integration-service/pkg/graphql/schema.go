@@ -0,0 +1,323 @@
+// Package graphql exposes a read-heavy GraphQL API over models.Integration
+// and the records its connector fetches, alongside the REST routes
+// main.go's setupServer already registers. Queries and the
+// integrationStatusChanged subscription share the same Resolver, which
+// resolves records through pkg/connectors rather than calling
+// zoho.ZohoClient or rms.RMSClient directly, so adding a connector makes
+// it available here for free.
+package graphql
+
+import (
+    "encoding/json"
+
+    "github.com/graphql-go/graphql" // v0.8.1
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
+)
+
+// jsonScalar represents an arbitrary JSON value (Integration.Config, a
+// connector record) as its decoded form, rather than as an opaque string,
+// so GraphQL clients can query into it like any other JSON response field.
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+    Name:        "JSON",
+    Description: "An arbitrary JSON value.",
+    Serialize: func(value interface{}) interface{} {
+        switch v := value.(type) {
+        case json.RawMessage:
+            var decoded interface{}
+            if err := json.Unmarshal(v, &decoded); err != nil {
+                return nil
+            }
+            return decoded
+        default:
+            return v
+        }
+    },
+})
+
+var pageInfoType = graphql.NewObject(graphql.ObjectConfig{
+    Name: "PageInfo",
+    Fields: graphql.Fields{
+        "hasNextPage": &graphql.Field{
+            Type: graphql.NewNonNull(graphql.Boolean),
+            Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                return p.Source.(pageInfo).hasNext, nil
+            },
+        },
+        "endCursor": &graphql.Field{
+            Type: graphql.String,
+            Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                return p.Source.(pageInfo).endCursor, nil
+            },
+        },
+    },
+})
+
+// pageInfo backs the PageInfo type for both connection types below.
+type pageInfo struct {
+    endCursor string
+    hasNext   bool
+}
+
+type integrationEdge struct {
+    cursor string
+    node   *models.Integration
+}
+
+type integrationConnection struct {
+    edges    []integrationEdge
+    pageInfo pageInfo
+}
+
+type recordEdge struct {
+    cursor string
+    node   map[string]interface{}
+}
+
+type recordConnection struct {
+    edges    []recordEdge
+    pageInfo pageInfo
+}
+
+// buildSchema assembles the GraphQL schema, binding every field resolver to
+// r. It is called once, from NewHandlers, rather than exposing the types as
+// package-level vars, since resolvers close over r instead of taking it as
+// an argument graphql-go has no room for.
+func buildSchema(r *Resolver) (graphql.Schema, error) {
+    recordEdgeType := graphql.NewObject(graphql.ObjectConfig{
+        Name: "RecordEdge",
+        Fields: graphql.Fields{
+            "cursor": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.String),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(recordEdge).cursor, nil
+                },
+            },
+            "node": &graphql.Field{
+                Type: graphql.NewNonNull(jsonScalar),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(recordEdge).node, nil
+                },
+            },
+        },
+    })
+
+    recordConnectionType := graphql.NewObject(graphql.ObjectConfig{
+        Name: "RecordConnection",
+        Fields: graphql.Fields{
+            "edges": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(recordEdgeType))),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(*recordConnection).edges, nil
+                },
+            },
+            "pageInfo": &graphql.Field{
+                Type: graphql.NewNonNull(pageInfoType),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(*recordConnection).pageInfo, nil
+                },
+            },
+        },
+    })
+
+    integrationType := graphql.NewObject(graphql.ObjectConfig{
+        Name: "Integration",
+        Fields: graphql.Fields{
+            "id": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.ID),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(*models.Integration).ID.String(), nil
+                },
+            },
+            "agentId": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.ID),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(*models.Integration).AgentID.String(), nil
+                },
+            },
+            "name": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.String),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(*models.Integration).Name, nil
+                },
+            },
+            "serviceType": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.String),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(*models.Integration).ServiceType, nil
+                },
+            },
+            "status": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.String),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(*models.Integration).Status, nil
+                },
+            },
+            "errorMessage": &graphql.Field{
+                Type: graphql.String,
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(*models.Integration).ErrorMessage, nil
+                },
+            },
+            "createdAt": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.DateTime),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(*models.Integration).CreatedAt, nil
+                },
+            },
+            "updatedAt": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.DateTime),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(*models.Integration).UpdatedAt, nil
+                },
+            },
+            "lastSyncAt": &graphql.Field{
+                Type: graphql.DateTime,
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(*models.Integration).LastSyncAt, nil
+                },
+            },
+            "retryCount": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.Int),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(*models.Integration).RetryCount, nil
+                },
+            },
+            // config exposes the integration's raw connector configuration,
+            // including credentials in encrypted/placeholder form; it is
+            // gated behind authorizeIntegrationAccess, as records is.
+            "config": &graphql.Field{
+                Type: jsonScalar,
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    integration := p.Source.(*models.Integration)
+                    if err := authorizeIntegrationAccess(p.Context, r.authManager, integration.ID); err != nil {
+                        return nil, err
+                    }
+                    return json.RawMessage(integration.Config), nil
+                },
+            },
+            // records is a Relay-style connection over connectors.Reader's
+            // page-at-a-time ListRecords, so `first`/`after` map directly
+            // onto ListOptions.Limit/Cursor instead of a second pagination
+            // scheme. Gated behind authorizeIntegrationAccess since records
+            // are live customer/CRM data from the connected service.
+            "records": &graphql.Field{
+                Type: graphql.NewNonNull(recordConnectionType),
+                Args: graphql.FieldConfigArgument{
+                    "resource": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+                    "first":    &graphql.ArgumentConfig{Type: graphql.Int},
+                    "after":    &graphql.ArgumentConfig{Type: graphql.String},
+                    "since":    &graphql.ArgumentConfig{Type: graphql.DateTime},
+                },
+                Resolve: r.resolveRecords,
+            },
+        },
+    })
+
+    integrationEdgeType := graphql.NewObject(graphql.ObjectConfig{
+        Name: "IntegrationEdge",
+        Fields: graphql.Fields{
+            "cursor": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.String),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(integrationEdge).cursor, nil
+                },
+            },
+            "node": &graphql.Field{
+                Type: graphql.NewNonNull(integrationType),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(integrationEdge).node, nil
+                },
+            },
+        },
+    })
+
+    integrationConnectionType := graphql.NewObject(graphql.ObjectConfig{
+        Name: "IntegrationConnection",
+        Fields: graphql.Fields{
+            "edges": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(integrationEdgeType))),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(*integrationConnection).edges, nil
+                },
+            },
+            "pageInfo": &graphql.Field{
+                Type: graphql.NewNonNull(pageInfoType),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(*integrationConnection).pageInfo, nil
+                },
+            },
+        },
+    })
+
+    integrationStatusEventType := graphql.NewObject(graphql.ObjectConfig{
+        Name: "IntegrationStatusEvent",
+        Fields: graphql.Fields{
+            "integrationId": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.ID),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(statusEvent).IntegrationID, nil
+                },
+            },
+            "status": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.String),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(statusEvent).Status, nil
+                },
+            },
+            "occurredAt": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.DateTime),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(statusEvent).OccurredAt, nil
+                },
+            },
+        },
+    })
+
+    queryType := graphql.NewObject(graphql.ObjectConfig{
+        Name: "Query",
+        Fields: graphql.Fields{
+            "integration": &graphql.Field{
+                Type: integrationType,
+                Args: graphql.FieldConfigArgument{
+                    "id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+                },
+                Resolve: r.resolveIntegration,
+            },
+            "integrations": &graphql.Field{
+                Type: graphql.NewNonNull(integrationConnectionType),
+                Args: graphql.FieldConfigArgument{
+                    "first":       &graphql.ArgumentConfig{Type: graphql.Int},
+                    "after":       &graphql.ArgumentConfig{Type: graphql.String},
+                    "serviceType": &graphql.ArgumentConfig{Type: graphql.String},
+                    "status":      &graphql.ArgumentConfig{Type: graphql.String},
+                },
+                Resolve: r.resolveIntegrations,
+            },
+        },
+    })
+
+    subscriptionType := graphql.NewObject(graphql.ObjectConfig{
+        Name: "Subscription",
+        Fields: graphql.Fields{
+            "integrationStatusChanged": &graphql.Field{
+                Type: graphql.NewNonNull(integrationStatusEventType),
+                Args: graphql.FieldConfigArgument{
+                    "id": &graphql.ArgumentConfig{Type: graphql.ID},
+                },
+                Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+                    filter, _ := p.Args["id"].(string)
+                    return subscribeStatusChanges(p.Context, filter), nil
+                },
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source, nil
+                },
+            },
+        },
+    })
+
+    return graphql.NewSchema(graphql.SchemaConfig{
+        Query:        queryType,
+        Subscription: subscriptionType,
+    })
+}
@@ -0,0 +1,59 @@
+package graphql
+
+import (
+    "net/http"
+    "strings"
+
+    gqlhandler "github.com/graphql-go/handler"       // v0.2.3
+    "github.com/prometheus/client_golang/prometheus" // v1.16.0
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/auth"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database"
+)
+
+// Handlers bundles the two HTTP entry points pkg/graphql exposes, so
+// main.go's setupServer can mount both alongside the existing REST routes
+// without reaching into pkg/graphql internals.
+type Handlers struct {
+    // Query serves queries and mutations over plain HTTP (GET/POST).
+    Query http.Handler
+    // Subscriptions serves the integrationStatusChanged websocket
+    // transport; see serveSubscription.
+    Subscriptions http.Handler
+}
+
+// NewHandlers builds the GraphQL schema and both HTTP entry points for it.
+// db, authManager, and metrics are the same dependencies pkg/connectors
+// needs to build a connector per integration.
+func NewHandlers(db *database.AtomicDB, authManager *auth.OAuthManager, metrics *prometheus.CounterVec) (*Handlers, error) {
+    resolver := NewResolver(db, authManager, metrics)
+    schema, err := buildSchema(resolver)
+    if err != nil {
+        return nil, err
+    }
+
+    query := gqlhandler.New(&gqlhandler.Config{
+        Schema:   &schema,
+        Pretty:   false,
+        GraphiQL: false,
+    })
+
+    return &Handlers{
+        Query:         withBearerToken(query),
+        Subscriptions: serveSubscription(schema),
+    }, nil
+}
+
+// withBearerToken extracts the request's "Authorization: Bearer <token>"
+// header and "X-Tenant-Id" header into its context before delegating to
+// next, so field resolvers (see authorizeIntegrationAccess) can check them
+// without graphql-go's resolver params needing to carry the raw
+// *http.Request.
+func withBearerToken(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+        ctx := contextWithBearerToken(r.Context(), token)
+        ctx = contextWithTenantID(ctx, r.Header.Get("X-Tenant-Id"))
+        next.ServeHTTP(w, r.WithContext(ctx))
+    })
+}
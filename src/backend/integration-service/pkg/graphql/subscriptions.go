@@ -0,0 +1,176 @@
+package graphql
+
+import (
+    "context"
+    "net/http"
+    "sync"
+    "time"
+
+    "github.com/google/uuid"         // v1.3.0
+    "github.com/gorilla/websocket"   // v1.5.0
+    "github.com/graphql-go/graphql"  // v0.8.1
+)
+
+// statusEvent is published whenever an Integration's Status field changes.
+type statusEvent struct {
+    IntegrationID string
+    Status        string
+    OccurredAt    time.Time
+}
+
+// statusBroker fans a published statusEvent out to every currently open
+// integrationStatusChanged subscription.
+type statusBroker struct {
+    mu   sync.Mutex
+    subs map[chan statusEvent]struct{}
+}
+
+func newStatusBroker() *statusBroker {
+    return &statusBroker{subs: make(map[chan statusEvent]struct{})}
+}
+
+func (b *statusBroker) subscribe() chan statusEvent {
+    ch := make(chan statusEvent, 8)
+    b.mu.Lock()
+    b.subs[ch] = struct{}{}
+    b.mu.Unlock()
+    return ch
+}
+
+func (b *statusBroker) unsubscribe(ch chan statusEvent) {
+    b.mu.Lock()
+    if _, ok := b.subs[ch]; ok {
+        delete(b.subs, ch)
+        close(ch)
+    }
+    b.mu.Unlock()
+}
+
+// publish delivers event to every subscriber, dropping it for a subscriber
+// whose buffer is full rather than blocking the publisher on a slow reader.
+func (b *statusBroker) publish(event statusEvent) {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    for ch := range b.subs {
+        select {
+        case ch <- event:
+        default:
+        }
+    }
+}
+
+var broker = newStatusBroker()
+
+// PublishStatusChange notifies any open integrationStatusChanged
+// subscriptions that integrationID's status is now status. Whatever
+// persists an Integration.Status change (pkg/heartbeat's liveness
+// monitor, a connector's sync pass, a manual status update) should call
+// this right alongside that write so subscribers see it without polling.
+func PublishStatusChange(integrationID uuid.UUID, status string) {
+    broker.publish(statusEvent{
+        IntegrationID: integrationID.String(),
+        Status:        status,
+        OccurredAt:    time.Now(),
+    })
+}
+
+// subscribeStatusChanges returns a channel of statusEvents matching
+// filterIntegrationID (or every event, if it's empty), closing the
+// channel and releasing the subscription once ctx is done.
+func subscribeStatusChanges(ctx context.Context, filterIntegrationID string) <-chan interface{} {
+    in := broker.subscribe()
+    out := make(chan interface{})
+
+    go func() {
+        defer broker.unsubscribe(in)
+        defer close(out)
+        for {
+            select {
+            case <-ctx.Done():
+                return
+            case event, ok := <-in:
+                if !ok {
+                    return
+                }
+                if filterIntegrationID != "" && event.IntegrationID != filterIntegrationID {
+                    continue
+                }
+                select {
+                case out <- event:
+                case <-ctx.Done():
+                    return
+                }
+            }
+        }
+    }()
+
+    return out
+}
+
+var upgrader = websocket.Upgrader{
+    ReadBufferSize:  4096,
+    WriteBufferSize: 4096,
+    // Subscriptions are authorized per-field by authorizeIntegrationAccess
+    // once the operation is running, the same as queries, so the origin
+    // check here is not a security boundary.
+    CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// serveSubscription upgrades r to a websocket and runs the single GraphQL
+// subscription operation sent as its first text frame, streaming each
+// result back as a JSON frame until the client disconnects or the
+// subscription channel closes. This is a minimal single-operation
+// transport built for integrationStatusChanged, not the full graphql-ws
+// subprotocol.
+func serveSubscription(schema graphql.Schema) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        conn, err := upgrader.Upgrade(w, r, nil)
+        if err != nil {
+            return
+        }
+        defer conn.Close()
+
+        var req struct {
+            Query     string                 `json:"query"`
+            Variables map[string]interface{} `json:"variables"`
+        }
+        if err := conn.ReadJSON(&req); err != nil {
+            return
+        }
+
+        token := r.URL.Query().Get("token")
+        tenantID := r.URL.Query().Get("tenant")
+        subCtx := contextWithTenantID(contextWithBearerToken(r.Context(), token), tenantID)
+        ctx, cancel := context.WithCancel(subCtx)
+        defer cancel()
+
+        go func() {
+            // This transport expects exactly one client message (the
+            // subscribe request already read above); anything else,
+            // including a close frame, ends the subscription.
+            _, _, _ = conn.ReadMessage()
+            cancel()
+        }()
+
+        results := graphql.Subscribe(graphql.Params{
+            Schema:         schema,
+            RequestString:  req.Query,
+            VariableValues: req.Variables,
+            Context:        ctx,
+        })
+
+        for {
+            select {
+            case <-ctx.Done():
+                return
+            case result, ok := <-results:
+                if !ok {
+                    return
+                }
+                if err := conn.WriteJSON(result); err != nil {
+                    return
+                }
+            }
+        }
+    })
+}
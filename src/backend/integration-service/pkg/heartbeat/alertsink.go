@@ -0,0 +1,82 @@
+package heartbeat
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "time"
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
+)
+
+// AlertSink is notified when an integration is found stale during a
+// Monitor scan. Implementations are expected to be fire-and-forget safe:
+// Monitor discards the returned error and does not retry within the same
+// scan.
+type AlertSink interface {
+    Alert(ctx context.Context, integration models.Integration, sinceLastSync time.Duration) error
+}
+
+// alertPayload is the JSON body posted to a WebhookAlertSink's URL.
+type alertPayload struct {
+    IntegrationID string  `json:"integration_id"`
+    AgentID       string  `json:"agent_id"`
+    ServiceType   string  `json:"service_type"`
+    SecondsStale  float64 `json:"seconds_stale"`
+    ErrorMessage  string  `json:"error_message"`
+}
+
+// WebhookAlertSink posts an alertPayload to a configured URL whenever an
+// integration is found stale, the way an on-call paging webhook (PagerDuty,
+// Slack, Opsgenie) expects to be driven.
+type WebhookAlertSink struct {
+    URL        string
+    HTTPClient *http.Client
+}
+
+// NewWebhookAlertSink creates a WebhookAlertSink posting to url. A default
+// HTTP client with a 10s timeout is used unless one is set directly on the
+// returned value.
+func NewWebhookAlertSink(url string) *WebhookAlertSink {
+    return &WebhookAlertSink{
+        URL:        url,
+        HTTPClient: &http.Client{Timeout: 10 * time.Second},
+    }
+}
+
+// Alert implements AlertSink.
+func (s *WebhookAlertSink) Alert(ctx context.Context, integration models.Integration, sinceLastSync time.Duration) error {
+    if s.URL == "" {
+        return fmt.Errorf("webhook alert sink: URL is not configured")
+    }
+
+    body, err := json.Marshal(alertPayload{
+        IntegrationID: integration.ID.String(),
+        AgentID:       integration.AgentID.String(),
+        ServiceType:   integration.ServiceType,
+        SecondsStale:  sinceLastSync.Seconds(),
+        ErrorMessage:  integration.ErrorMessage,
+    })
+    if err != nil {
+        return fmt.Errorf("webhook alert sink: failed to marshal payload: %w", err)
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+    if err != nil {
+        return fmt.Errorf("webhook alert sink: failed to build request: %w", err)
+    }
+    req.Header.Set("Content-Type", "application/json")
+
+    resp, err := s.HTTPClient.Do(req)
+    if err != nil {
+        return fmt.Errorf("webhook alert sink: request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode >= 400 {
+        return fmt.Errorf("webhook alert sink: received status %d", resp.StatusCode)
+    }
+    return nil
+}
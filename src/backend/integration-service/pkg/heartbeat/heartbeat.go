@@ -0,0 +1,211 @@
+// Package heartbeat watches integration liveness. Each connector declares
+// the checkin interval it expects (see RegisterInterval); a Monitor then
+// periodically scans integrations for ones whose Integration.LastSyncAt has
+// fallen behind that interval, marks them StatusError, and alerts an
+// AlertSink. Liveness itself is derived from real traffic via Checkin,
+// called from each connector's successful request paths, not from a
+// synthetic ping.
+package heartbeat
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/prometheus/client_golang/prometheus"
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database"
+)
+
+const (
+    // defaultTolerance multiplies a connector's declared interval before an
+    // integration is considered stale, absorbing normal jitter in poll
+    // timing rather than flagging on the first missed beat.
+    defaultTolerance = 2.0
+
+    // defaultScanInterval is how often Monitor.Run re-scans integrations.
+    defaultScanInterval = 30 * time.Second
+)
+
+var intervalRegistry = struct {
+    mu        sync.RWMutex
+    intervals map[string]time.Duration
+}{
+    intervals: make(map[string]time.Duration),
+}
+
+// RegisterInterval declares the expected checkin interval for serviceType.
+// Connector packages call this from an init(), alongside
+// models.RegisterServiceType and connectors.Register, e.g. RMS registers its
+// 60s orders-poll cadence and Zoho CRM its 45m records-request cadence.
+func RegisterInterval(serviceType string, interval time.Duration) {
+    intervalRegistry.mu.Lock()
+    defer intervalRegistry.mu.Unlock()
+    intervalRegistry.intervals[serviceType] = interval
+}
+
+// IntervalFor returns the declared checkin interval for serviceType, if any.
+func IntervalFor(serviceType string) (time.Duration, bool) {
+    intervalRegistry.mu.RLock()
+    defer intervalRegistry.mu.RUnlock()
+    d, ok := intervalRegistry.intervals[serviceType]
+    return d, ok
+}
+
+// Checkin records that integrationID just completed a successful request,
+// so the Monitor's staleness scan starts its clock from now. Connector
+// clients call this from every successful GetRecords/GetOrders/CreateRecord
+// path, right alongside their metrics. If ctx carries a transaction handle
+// (see database.WithTx), the checkin is recorded inside it rather than
+// against db directly, so it commits or rolls back with the rest of the
+// caller's work. db is an *database.AtomicDB, resolved fresh via Get() here,
+// so a CredentialManager rotating the connection pool mid-flight never
+// leaves a checkin racing against a closed pool.
+func Checkin(ctx context.Context, db *database.AtomicDB, integrationID uuid.UUID) error {
+    now := time.Now()
+    result := database.DBFromContext(ctx, db.Get()).WithContext(ctx).
+        Model(&models.Integration{}).
+        Where("id = ?", integrationID).
+        Update("last_sync_at", now)
+    if result.Error != nil {
+        return fmt.Errorf("heartbeat: failed to record checkin: %w", result.Error)
+    }
+    return nil
+}
+
+// metrics holds the Prometheus collectors a Monitor reports through.
+type metrics struct {
+    secondsSinceLastSync *prometheus.GaugeVec
+    missedCheckins       *prometheus.CounterVec
+}
+
+func newMetrics() *metrics {
+    return &metrics{
+        secondsSinceLastSync: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+            Name: "integration_seconds_since_last_sync",
+            Help: "Seconds since the integration's last successful checkin.",
+        }, []string{"service_type", "integration_id"}),
+        missedCheckins: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Name: "integration_missed_checkins_total",
+            Help: "Number of times an integration was found stale during a heartbeat scan.",
+        }, []string{"service_type", "integration_id"}),
+    }
+}
+
+// Monitor periodically scans integrations for staleness relative to their
+// connector's declared checkin interval.
+type Monitor struct {
+    db        *database.AtomicDB
+    alertSink AlertSink
+    tolerance float64
+
+    scanInterval time.Duration
+    metrics      *metrics
+}
+
+// NewMonitor creates a Monitor that scans db every scanInterval (0 defaults
+// to 30s), alerting through alertSink when an integration goes stale.
+// alertSink may be nil, in which case staleness is still recorded in
+// metrics and Integration.Status but nothing is notified externally.
+func NewMonitor(db *database.AtomicDB, alertSink AlertSink, scanInterval time.Duration) (*Monitor, error) {
+    if db == nil {
+        return nil, fmt.Errorf("heartbeat: db is required")
+    }
+    if scanInterval <= 0 {
+        scanInterval = defaultScanInterval
+    }
+
+    m := &Monitor{
+        db:           db,
+        alertSink:    alertSink,
+        tolerance:    defaultTolerance,
+        scanInterval: scanInterval,
+        metrics:      newMetrics(),
+    }
+
+    if err := prometheus.Register(m.metrics.secondsSinceLastSync); err != nil {
+        if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+            return nil, fmt.Errorf("heartbeat: failed to register metrics: %w", err)
+        }
+    }
+    if err := prometheus.Register(m.metrics.missedCheckins); err != nil {
+        if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+            return nil, fmt.Errorf("heartbeat: failed to register metrics: %w", err)
+        }
+    }
+
+    return m, nil
+}
+
+// Run scans integrations every m.scanInterval until ctx is canceled.
+func (m *Monitor) Run(ctx context.Context) {
+    ticker := time.NewTicker(m.scanInterval)
+    defer ticker.Stop()
+
+    for {
+        m.scan(ctx)
+
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+    }
+}
+
+// scan runs a single staleness pass over every integration that has a
+// registered checkin interval.
+func (m *Monitor) scan(ctx context.Context) {
+    var integrations []models.Integration
+    if err := m.db.Get().WithContext(ctx).Find(&integrations).Error; err != nil {
+        return
+    }
+
+    now := time.Now()
+    for _, integration := range integrations {
+        interval, ok := IntervalFor(integration.ServiceType)
+        if !ok {
+            continue
+        }
+        m.checkIntegration(ctx, integration, interval, now)
+    }
+}
+
+func (m *Monitor) checkIntegration(ctx context.Context, integration models.Integration, interval time.Duration, now time.Time) {
+    labels := prometheus.Labels{
+        "service_type":   integration.ServiceType,
+        "integration_id": integration.ID.String(),
+    }
+
+    if integration.LastSyncAt == nil {
+        // Never checked in yet; nothing to measure staleness against.
+        return
+    }
+
+    sinceLastSync := now.Sub(*integration.LastSyncAt)
+    m.metrics.secondsSinceLastSync.With(labels).Set(sinceLastSync.Seconds())
+
+    staleAfter := time.Duration(float64(interval) * m.tolerance)
+    if sinceLastSync <= staleAfter {
+        return
+    }
+
+    m.metrics.missedCheckins.With(labels).Inc()
+
+    if integration.Status != models.StatusError {
+        errMsg := fmt.Sprintf("no successful checkin in %s (expected every %s)", sinceLastSync.Round(time.Second), interval)
+        if err := integration.UpdateStatus(models.StatusError, errMsg); err == nil {
+            m.db.Get().WithContext(ctx).Save(&integration)
+        }
+    }
+
+    if m.alertSink == nil {
+        return
+    }
+    // Best-effort: a failed alert delivery should not block the rest of the
+    // scan. The next scan will retry for as long as the integration stays stale.
+    _ = m.alertSink.Alert(ctx, integration, sinceLastSync)
+}
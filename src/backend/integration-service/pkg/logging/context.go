@@ -0,0 +1,28 @@
+// Package logging provides the structured slog.Logger plumbing shared
+// across integration-service's binaries: a context.Context carrier so a
+// request-scoped logger (with a correlation ID attached) reaches deep call
+// sites without threading it through every function signature, and a
+// DedupHandler that keeps retry storms from flooding stdout.
+package logging
+
+import (
+    "context"
+    "log/slog"
+)
+
+type contextKey struct{}
+
+// WithContext returns a copy of ctx carrying logger, retrievable with
+// FromContext.
+func WithContext(ctx context.Context, logger *slog.Logger) context.Context {
+    return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger ctx carries, or slog.Default() if ctx
+// (or none of its ancestors) was ever given one via WithContext.
+func FromContext(ctx context.Context) *slog.Logger {
+    if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+        return logger
+    }
+    return slog.Default()
+}
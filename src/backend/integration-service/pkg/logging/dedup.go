@@ -0,0 +1,107 @@
+package logging
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "log/slog"
+    "sync"
+    "time"
+)
+
+// DedupHandler wraps a slog.Handler and suppresses identical records —
+// same level, message, and attribute set — seen again within window of
+// the first occurrence. The first occurrence is emitted immediately;
+// once window elapses, a single summarizing record is emitted with a
+// count attribute added if any duplicates were suppressed. This keeps a
+// retry storm (getToken logging the same "auth: rate limit exceeded"
+// warning for every request an integration's bucket turns away, for one)
+// from flooding stdout.
+type DedupHandler struct {
+    next   slog.Handler
+    window time.Duration
+
+    mu      sync.Mutex
+    pending map[string]*dedupEntry
+}
+
+type dedupEntry struct {
+    ctx    context.Context
+    record slog.Record
+    count  int
+}
+
+// NewDedupHandler wraps next, suppressing duplicate records seen again
+// within window of the first occurrence.
+func NewDedupHandler(next slog.Handler, window time.Duration) *DedupHandler {
+    return &DedupHandler{
+        next:    next,
+        window:  window,
+        pending: make(map[string]*dedupEntry),
+    }
+}
+
+// Enabled implements slog.Handler.
+func (h *DedupHandler) Enabled(ctx context.Context, level slog.Level) bool {
+    return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler, deduplicating by (level, message, attrs).
+func (h *DedupHandler) Handle(ctx context.Context, record slog.Record) error {
+    key := dedupKey(record)
+
+    h.mu.Lock()
+    if entry, ok := h.pending[key]; ok {
+        entry.count++
+        h.mu.Unlock()
+        return nil
+    }
+    h.pending[key] = &dedupEntry{ctx: ctx, record: record.Clone(), count: 1}
+    h.mu.Unlock()
+
+    time.AfterFunc(h.window, func() { h.flush(key) })
+    return h.next.Handle(ctx, record)
+}
+
+// flush emits a count=N record for key's suppressed duplicates, if any,
+// and forgets the entry so the next occurrence starts a fresh window.
+func (h *DedupHandler) flush(key string) {
+    h.mu.Lock()
+    entry, ok := h.pending[key]
+    if ok {
+        delete(h.pending, key)
+    }
+    h.mu.Unlock()
+
+    if !ok || entry.count <= 1 {
+        return
+    }
+
+    record := entry.record.Clone()
+    record.Time = time.Now()
+    record.AddAttrs(slog.Int("count", entry.count))
+    h.next.Handle(entry.ctx, record)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *DedupHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+    return NewDedupHandler(h.next.WithAttrs(attrs), h.window)
+}
+
+// WithGroup implements slog.Handler.
+func (h *DedupHandler) WithGroup(name string) slog.Handler {
+    return NewDedupHandler(h.next.WithGroup(name), h.window)
+}
+
+// dedupKey hashes record's level, message, and attributes so two records
+// differing only in timestamp compare equal.
+func dedupKey(record slog.Record) string {
+    h := sha256.New()
+    fmt.Fprintf(h, "%d|%s|", record.Level, record.Message)
+    record.Attrs(func(a slog.Attr) bool {
+        fmt.Fprintf(h, "%s=%v|", a.Key, a.Value)
+        return true
+    })
+    return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,30 @@
+package logging
+
+import (
+    "log/slog"
+
+    "github.com/gofiber/fiber/v2"  // v2.47.0
+    "github.com/google/uuid"       // v1.3.0
+)
+
+// RequestIDHeader is the response header RequestID echoes the generated
+// correlation ID on, for clients and downstream services to log alongside
+// their own records.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID returns Fiber middleware that generates a UUID v4 request ID,
+// attaches it to logger as a "request_id" attribute, and stashes the
+// resulting logger on c.UserContext() so handlers and anything they call
+// can retrieve it with logging.FromContext instead of threading it
+// through every function signature.
+func RequestID(logger *slog.Logger) fiber.Handler {
+    return func(c *fiber.Ctx) error {
+        requestID := uuid.New().String()
+        c.Set(RequestIDHeader, requestID)
+
+        reqLogger := logger.With("request_id", requestID)
+        c.SetUserContext(WithContext(c.UserContext(), reqLogger))
+
+        return c.Next()
+    }
+}
@@ -0,0 +1,40 @@
+package secrets
+
+import (
+    "context"
+    "fmt"
+    "net/url"
+    "os"
+)
+
+func init() {
+    RegisterProvider("env", newEnvProvider)
+}
+
+// envProvider reads the key from an environment variable, named by the
+// host component of the key-provider URL (e.g. "env://ENCRYPTION_KEY").
+type envProvider struct {
+    varName string
+}
+
+func newEnvProvider(u *url.URL) (KeyProvider, error) {
+    varName := u.Host
+    if varName == "" {
+        return nil, fmt.Errorf("secrets: env provider requires a variable name, got %q", u.String())
+    }
+    return &envProvider{varName: varName}, nil
+}
+
+// Key implements KeyProvider. The env provider has only one key version
+// ("v1"); rotating it means setting a new value and restarting the process.
+func (p *envProvider) Key(ctx context.Context, keyID string) ([]byte, string, error) {
+    if keyID != "" && keyID != "v1" {
+        return nil, "", fmt.Errorf("secrets: env provider has no key version %q", keyID)
+    }
+
+    value := os.Getenv(p.varName)
+    if value == "" {
+        return nil, "", fmt.Errorf("secrets: environment variable %s is not set", p.varName)
+    }
+    return []byte(value), "v1", nil
+}
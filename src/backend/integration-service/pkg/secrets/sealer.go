@@ -0,0 +1,163 @@
+package secrets
+
+import (
+    "context"
+    "crypto/aes"
+    "crypto/cipher"
+    "crypto/rand"
+    "encoding/base64"
+    "encoding/json"
+    "fmt"
+)
+
+const (
+    sealedVersion = 1
+    dekSize       = 32 // AES-256
+)
+
+// SealedValue is the on-disk envelope-encryption format: a fresh
+// data-encryption key (DEK) encrypts the plaintext, and the DEK itself is
+// wrapped under a KeyProvider-resolved KEK. A config.yaml field holds this
+// struct JSON-marshaled and base64-encoded.
+type SealedValue struct {
+    Version    int    `json:"version"`
+    KEKID      string `json:"kek_id"`
+    WrappedDEK []byte `json:"wrapped_dek"` // KEK nonce || KEK-ciphertext(DEK)
+    Nonce      []byte `json:"nonce"`       // DEK nonce for Ciphertext
+    Ciphertext []byte `json:"ciphertext"`  // DEK-ciphertext(plaintext)
+    AAD        []byte `json:"aad,omitempty"`
+}
+
+// Sealer performs envelope encryption against a KeyProvider-resolved KEK:
+// every Seal call generates its own DEK, so compromising one sealed value
+// never exposes any other.
+type Sealer struct {
+    provider KeyProvider
+}
+
+// NewSealer builds a Sealer that wraps data keys with provider's current
+// key.
+func NewSealer(provider KeyProvider) *Sealer {
+    return &Sealer{provider: provider}
+}
+
+// Seal encrypts plaintext under a fresh DEK, wraps the DEK with the
+// provider's current KEK, and returns the base64-encoded SealedValue.
+// aad, if non-nil, is authenticated (but not encrypted) alongside the
+// ciphertext and is stored in the SealedValue, which Open authenticates
+// against.
+func (s *Sealer) Seal(ctx context.Context, plaintext string, aad []byte) (string, error) {
+    dek := make([]byte, dekSize)
+    if _, err := rand.Read(dek); err != nil {
+        return "", fmt.Errorf("secrets: failed to generate data key: %w", err)
+    }
+
+    dekGCM, err := newGCM(dek)
+    if err != nil {
+        return "", fmt.Errorf("secrets: failed to initialize data key cipher: %w", err)
+    }
+    nonce := make([]byte, dekGCM.NonceSize())
+    if _, err := rand.Read(nonce); err != nil {
+        return "", fmt.Errorf("secrets: failed to generate nonce: %w", err)
+    }
+    ciphertext := dekGCM.Seal(nil, nonce, []byte(plaintext), aad)
+
+    kek, kekID, err := s.provider.Key(ctx, "")
+    if err != nil {
+        return "", fmt.Errorf("secrets: failed to resolve KEK: %w", err)
+    }
+    kekGCM, err := newGCM(kek)
+    if err != nil {
+        return "", fmt.Errorf("secrets: failed to initialize KEK cipher: %w", err)
+    }
+    kekNonce := make([]byte, kekGCM.NonceSize())
+    if _, err := rand.Read(kekNonce); err != nil {
+        return "", fmt.Errorf("secrets: failed to generate KEK nonce: %w", err)
+    }
+    wrappedDEK := kekGCM.Seal(kekNonce, kekNonce, dek, nil)
+
+    sv := SealedValue{
+        Version:    sealedVersion,
+        KEKID:      kekID,
+        WrappedDEK: wrappedDEK,
+        Nonce:      nonce,
+        Ciphertext: ciphertext,
+        AAD:        aad,
+    }
+    data, err := json.Marshal(sv)
+    if err != nil {
+        return "", fmt.Errorf("secrets: failed to marshal sealed value: %w", err)
+    }
+    return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// Open reverses Seal: it unwraps the DEK with the KEK named by the sealed
+// value's kek_id (so a rotated current KEK doesn't break values sealed
+// under an earlier one), then decrypts the ciphertext.
+func (s *Sealer) Open(ctx context.Context, sealed string) (string, error) {
+    sv, err := ParseSealedValue(sealed)
+    if err != nil {
+        return "", err
+    }
+
+    kek, _, err := s.provider.Key(ctx, sv.KEKID)
+    if err != nil {
+        return "", fmt.Errorf("secrets: failed to resolve KEK %q: %w", sv.KEKID, err)
+    }
+    kekGCM, err := newGCM(kek)
+    if err != nil {
+        return "", fmt.Errorf("secrets: failed to initialize KEK cipher: %w", err)
+    }
+    if len(sv.WrappedDEK) < kekGCM.NonceSize() {
+        return "", fmt.Errorf("secrets: wrapped data key is too short")
+    }
+    kekNonce := sv.WrappedDEK[:kekGCM.NonceSize()]
+    wrapped := sv.WrappedDEK[kekGCM.NonceSize():]
+    dek, err := kekGCM.Open(nil, kekNonce, wrapped, nil)
+    if err != nil {
+        return "", fmt.Errorf("secrets: failed to unwrap data key: %w", err)
+    }
+
+    dekGCM, err := newGCM(dek)
+    if err != nil {
+        return "", fmt.Errorf("secrets: failed to initialize data key cipher: %w", err)
+    }
+    plaintext, err := dekGCM.Open(nil, sv.Nonce, sv.Ciphertext, sv.AAD)
+    if err != nil {
+        return "", fmt.Errorf("secrets: failed to decrypt sealed value: %w", err)
+    }
+    return string(plaintext), nil
+}
+
+// ParseSealedValue decodes sealed's base64 envelope without decrypting it.
+func ParseSealedValue(sealed string) (SealedValue, error) {
+    raw, err := base64.StdEncoding.DecodeString(sealed)
+    if err != nil {
+        return SealedValue{}, fmt.Errorf("secrets: failed to decode sealed value: %w", err)
+    }
+
+    var sv SealedValue
+    if err := json.Unmarshal(raw, &sv); err != nil {
+        return SealedValue{}, fmt.Errorf("secrets: failed to parse sealed value: %w", err)
+    }
+    if sv.Version != sealedVersion {
+        return SealedValue{}, fmt.Errorf("secrets: unsupported sealed value version %d", sv.Version)
+    }
+    return sv, nil
+}
+
+// IsSealed reports whether value looks like a Sealer-produced envelope, as
+// opposed to a legacy single-key-prefixed value from before envelope
+// encryption was introduced.
+func IsSealed(value string) bool {
+    _, err := ParseSealedValue(value)
+    return err == nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+    block, err := aes.NewCipher(key)
+    if err != nil {
+        return nil, err
+    }
+    return cipher.NewGCM(block)
+}
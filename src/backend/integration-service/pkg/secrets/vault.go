@@ -0,0 +1,133 @@
+package secrets
+
+import (
+    "context"
+    "encoding/base64"
+    "fmt"
+    "net/url"
+    "os"
+    "strings"
+
+    vaultapi "github.com/hashicorp/vault/api" // v1.9.2
+)
+
+func init() {
+    RegisterProvider("vault", newVaultProvider)
+}
+
+// vaultProvider resolves keys from HashiCorp Vault. Paths under "transit/"
+// are unwrapped through Vault's Transit secrets engine (the key never
+// leaves Vault); any other path is read as a KV v2 secret whose "key" field
+// holds the raw key bytes, base64-encoded.
+type vaultProvider struct {
+    client *vaultapi.Client
+    path   string
+}
+
+func newVaultProvider(u *url.URL) (KeyProvider, error) {
+    address := os.Getenv("VAULT_ADDR")
+    if address == "" {
+        return nil, fmt.Errorf("secrets: VAULT_ADDR is not set")
+    }
+    token := os.Getenv("VAULT_TOKEN")
+    if token == "" {
+        return nil, fmt.Errorf("secrets: VAULT_TOKEN is not set")
+    }
+
+    clientCfg := vaultapi.DefaultConfig()
+    clientCfg.Address = address
+    client, err := vaultapi.NewClient(clientCfg)
+    if err != nil {
+        return nil, fmt.Errorf("secrets: failed to create vault client: %w", err)
+    }
+    client.SetToken(token)
+
+    path := strings.TrimPrefix(u.Path, "/")
+    if u.Host != "" {
+        path = u.Host + "/" + path
+    }
+    if path == "" {
+        return nil, fmt.Errorf("secrets: vault provider requires a secret path, got %q", u.String())
+    }
+
+    return &vaultProvider{client: client, path: path}, nil
+}
+
+// Key implements KeyProvider.
+func (p *vaultProvider) Key(ctx context.Context, keyID string) ([]byte, string, error) {
+    if strings.HasPrefix(p.path, "transit/") {
+        return p.unwrapTransit(ctx, keyID)
+    }
+    return p.readKV(ctx, keyID)
+}
+
+// unwrapTransit asks Vault's Transit engine to decrypt the ciphertext
+// stored under p.path, returning the plaintext key without Vault ever
+// releasing the underlying key material.
+func (p *vaultProvider) unwrapTransit(ctx context.Context, keyID string) ([]byte, string, error) {
+    secret, err := p.client.Logical().ReadWithContext(ctx, p.path)
+    if err != nil {
+        return nil, "", fmt.Errorf("secrets: vault transit read failed: %w", err)
+    }
+    if secret == nil || secret.Data == nil {
+        return nil, "", fmt.Errorf("secrets: vault transit path %s returned no data", p.path)
+    }
+
+    ciphertext, _ := secret.Data["ciphertext"].(string)
+    if ciphertext == "" {
+        return nil, "", fmt.Errorf("secrets: vault transit path %s has no ciphertext field", p.path)
+    }
+
+    decryptPath := strings.Replace(p.path, "/encrypt/", "/decrypt/", 1)
+    resp, err := p.client.Logical().WriteWithContext(ctx, decryptPath, map[string]interface{}{
+        "ciphertext": ciphertext,
+    })
+    if err != nil {
+        return nil, "", fmt.Errorf("secrets: vault transit decrypt failed: %w", err)
+    }
+
+    plaintext, _ := resp.Data["plaintext"].(string)
+    if plaintext == "" {
+        return nil, "", fmt.Errorf("secrets: vault transit decrypt returned no plaintext")
+    }
+    raw, err := base64.StdEncoding.DecodeString(plaintext)
+    if err != nil {
+        return nil, "", fmt.Errorf("secrets: vault transit path %s returned non-base64 plaintext: %w", p.path, err)
+    }
+    return raw, resolveKeyID(keyID), nil
+}
+
+// readKV reads p.path as a KV v2 secret and returns its "key" field.
+func (p *vaultProvider) readKV(ctx context.Context, keyID string) ([]byte, string, error) {
+    secret, err := p.client.Logical().ReadWithContext(ctx, p.path)
+    if err != nil {
+        return nil, "", fmt.Errorf("secrets: vault kv read failed: %w", err)
+    }
+    if secret == nil || secret.Data == nil {
+        return nil, "", fmt.Errorf("secrets: vault path %s returned no data", p.path)
+    }
+
+    data, ok := secret.Data["data"].(map[string]interface{})
+    if !ok {
+        data = secret.Data
+    }
+
+    key, _ := data["key"].(string)
+    if key == "" {
+        return nil, "", fmt.Errorf("secrets: vault path %s has no \"key\" field", p.path)
+    }
+    raw, err := base64.StdEncoding.DecodeString(key)
+    if err != nil {
+        return nil, "", fmt.Errorf("secrets: vault path %s has a non-base64 \"key\" field: %w", p.path, err)
+    }
+    return raw, resolveKeyID(keyID), nil
+}
+
+// resolveKeyID defaults an unspecified keyID to "v1" so callers always get
+// back a concrete version to tag newly-encrypted values with.
+func resolveKeyID(keyID string) string {
+    if keyID == "" {
+        return "v1"
+    }
+    return keyID
+}
@@ -0,0 +1,257 @@
+// Package testutil provides a reusable integration-test harness so that
+// connector packages (and future ones) do not each have to re-derive
+// container lifecycle, database setup, and mock-service plumbing the way
+// test/integration_test.go's TestMain used to.
+package testutil
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "log/slog"
+    "os"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/testcontainers/testcontainers-go"
+    "github.com/testcontainers/testcontainers-go/wait"
+    "gorm.io/gorm"
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/config"
+    "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database"
+)
+
+const (
+    postgresImage    = "postgres:15-alpine"
+    postgresUser     = "testutil"
+    postgresPassword = "testutil"
+    postgresDB       = "testutil"
+    containerTimeout = 60 * time.Second
+)
+
+// Harness bundles the dependencies a connector integration test needs: a
+// live database, a private Prometheus registry, a logger, a Config wired to
+// point at mock external services, and WireMock-style stubs for Zoho CRM
+// and RMS.
+type Harness struct {
+    DB       *gorm.DB
+    Registry *prometheus.Registry
+    Logger   *slog.Logger
+    Config   *config.Config
+
+    t          *testing.T
+    container  testcontainers.Container
+    rootDB     *gorm.DB
+    tx         *gorm.DB
+    zohoMock   *MockServer
+    rmsMock    *MockServer
+    savepoints int
+}
+
+// HarnessOption customizes a Harness before it is returned by NewHarness.
+type HarnessOption func(*harnessOptions)
+
+type harnessOptions struct {
+    skipDB bool
+}
+
+// WithoutDatabase skips starting a Postgres container, for tests that only
+// need the mock HTTP servers and a Config.
+func WithoutDatabase() HarnessOption {
+    return func(o *harnessOptions) { o.skipDB = true }
+}
+
+// NewHarness builds a Harness for the duration of t, registering cleanup so
+// the Postgres container and mock servers are torn down when t finishes.
+func NewHarness(t *testing.T, opts ...HarnessOption) *Harness {
+    t.Helper()
+
+    options := &harnessOptions{}
+    for _, opt := range opts {
+        opt(options)
+    }
+
+    logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+    h := &Harness{
+        Registry: prometheus.NewRegistry(),
+        Logger:   logger,
+        zohoMock: newMockServer(t),
+        rmsMock:  newMockServer(t),
+        t:        t,
+    }
+
+    h.Config = h.buildConfig()
+
+    if !options.skipDB {
+        h.setupDatabase(t)
+    }
+
+    return h
+}
+
+// buildConfig assembles a config.Config pointing Zoho CRM and RMS at the
+// harness's mock servers, bypassing config.LoadConfig (which requires a
+// file on disk and validated TLS material the harness has no use for).
+func (h *Harness) buildConfig() *config.Config {
+    return &config.Config{
+        Version: "test",
+        ZohoCRMConfig: config.ZohoCRMConfig{
+            BaseURL:      h.zohoMock.URL(),
+            ClientID:     "test-client-id",
+            ClientSecret: "test-client-secret",
+            RefreshToken: "test-refresh-token",
+            Timeout:      5 * time.Second,
+        },
+        RMSConfig: config.RMSConfig{
+            BaseURL:    h.rmsMock.URL(),
+            APIKey:     "test-api-key",
+            APIVersion: "v1",
+            Timeout:    5 * time.Second,
+        },
+        SecurityConfig: config.SecurityConfig{
+            EncryptionKey: "01234567890123456789012345678901",
+        },
+    }
+}
+
+// setupDatabase starts a Postgres container, runs the schema migration the
+// daemon itself relies on, and opens the long-lived transaction every
+// subtest's Snapshot call takes a SAVEPOINT within.
+func (h *Harness) setupDatabase(t *testing.T) {
+    t.Helper()
+
+    ctx, cancel := context.WithTimeout(context.Background(), containerTimeout)
+    defer cancel()
+
+    req := testcontainers.ContainerRequest{
+        Image:        postgresImage,
+        ExposedPorts: []string{"5432/tcp"},
+        Env: map[string]string{
+            "POSTGRES_USER":     postgresUser,
+            "POSTGRES_PASSWORD": postgresPassword,
+            "POSTGRES_DB":       postgresDB,
+        },
+        WaitingFor: wait.ForListeningPort("5432/tcp").WithStartupTimeout(containerTimeout),
+    }
+
+    container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+        ContainerRequest: req,
+        Started:          true,
+    })
+    if err != nil {
+        t.Fatalf("testutil: failed to start postgres container: %v", err)
+    }
+    h.container = container
+    t.Cleanup(func() {
+        if err := container.Terminate(context.Background()); err != nil {
+            t.Logf("testutil: failed to terminate postgres container: %v", err)
+        }
+    })
+
+    host, err := container.Host(ctx)
+    if err != nil {
+        t.Fatalf("testutil: failed to resolve postgres host: %v", err)
+    }
+    port, err := container.MappedPort(ctx, "5432/tcp")
+    if err != nil {
+        t.Fatalf("testutil: failed to resolve postgres port: %v", err)
+    }
+
+    dbCfg := config.DatabaseConfig{
+        Host:     host,
+        Port:     port.Int(),
+        Name:     postgresDB,
+        User:     postgresUser,
+        Password: postgresPassword,
+        SSLMode:  "disable",
+    }
+    h.Config.DatabaseConfig = dbCfg
+
+    rootDB, err := database.NewPostgresDB(&dbCfg)
+    if err != nil {
+        t.Fatalf("testutil: failed to connect to postgres: %v", err)
+    }
+    h.rootDB = rootDB
+    t.Cleanup(func() {
+        if err := database.Close(rootDB); err != nil {
+            t.Logf("testutil: failed to close root db connection: %v", err)
+        }
+    })
+
+    h.tx = rootDB.Begin()
+    if h.tx.Error != nil {
+        t.Fatalf("testutil: failed to begin root transaction: %v", h.tx.Error)
+    }
+    h.DB = h.tx
+    t.Cleanup(func() {
+        if err := h.tx.Rollback().Error; err != nil {
+            t.Logf("testutil: failed to roll back root transaction: %v", err)
+        }
+    })
+}
+
+// Snapshot wraps the remainder of t (typically a t.Run subtest) in a
+// SAVEPOINT, rolling back to it via t.Cleanup so each subtest sees the same
+// starting state regardless of what earlier subtests wrote.
+func (h *Harness) Snapshot(t *testing.T) *gorm.DB {
+    t.Helper()
+
+    if h.tx == nil {
+        t.Fatalf("testutil: Snapshot requires a harness created without WithoutDatabase()")
+    }
+
+    h.savepoints++
+    name := fmt.Sprintf("testutil_sp_%d", h.savepoints)
+
+    if err := h.tx.SavePoint(name).Error; err != nil {
+        t.Fatalf("testutil: failed to create savepoint %s: %v", name, err)
+    }
+    t.Cleanup(func() {
+        if err := h.tx.RollbackTo(name).Error; err != nil {
+            t.Logf("testutil: failed to roll back savepoint %s: %v", name, err)
+        }
+    })
+
+    return h.tx
+}
+
+// SeedIntegration persists a models.Integration of serviceType with cfg
+// marshaled as its Config payload, the way an operator's onboarding flow
+// would, so tests exercise the same validation path production traffic
+// does.
+func (h *Harness) SeedIntegration(serviceType string, cfg interface{}) (*models.Integration, error) {
+    payload, err := json.Marshal(cfg)
+    if err != nil {
+        return nil, fmt.Errorf("testutil: failed to marshal integration config: %w", err)
+    }
+
+    integration := &models.Integration{
+        ID:          uuid.New(),
+        AgentID:     uuid.New(),
+        TenantID:    "testutil-tenant",
+        Name:        fmt.Sprintf("testutil %s integration", serviceType),
+        ServiceType: serviceType,
+        Status:      models.StatusInactive,
+        Config:      payload,
+    }
+
+    if err := h.DB.Create(integration).Error; err != nil {
+        return nil, fmt.Errorf("testutil: failed to seed integration: %w", err)
+    }
+
+    return integration, nil
+}
+
+// ZohoMock returns the Harness's stub server for Zoho CRM's REST API.
+func (h *Harness) ZohoMock() *MockServer {
+    return h.zohoMock
+}
+
+// RMSMock returns the Harness's stub server for the RMS API.
+func (h *Harness) RMSMock() *MockServer {
+    return h.rmsMock
+}
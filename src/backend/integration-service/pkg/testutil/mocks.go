@@ -0,0 +1,82 @@
+package testutil
+
+import (
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "sync"
+    "testing"
+)
+
+// MockServer is a minimal WireMock-style stub server: register a response
+// for a method+path pair with Stub, and requests that don't match any stub
+// get a 404 with a body naming the unmatched route, so a missing stub fails
+// loudly instead of silently returning a zero value.
+type MockServer struct {
+    server *httptest.Server
+    mu     sync.Mutex
+    stubs  map[string]stubbedResponse
+}
+
+type stubbedResponse struct {
+    status int
+    body   []byte
+}
+
+// newMockServer starts an httptest.Server and registers its shutdown with
+// t.Cleanup.
+func newMockServer(t *testing.T) *MockServer {
+    t.Helper()
+
+    m := &MockServer{stubs: make(map[string]stubbedResponse)}
+    m.server = httptest.NewServer(http.HandlerFunc(m.handle))
+    t.Cleanup(m.server.Close)
+    return m
+}
+
+// URL returns the mock server's base URL, suitable for a Config's BaseURL
+// field.
+func (m *MockServer) URL() string {
+    return m.server.URL
+}
+
+// Stub registers the response returned for method+path. body is marshaled
+// as JSON.
+func (m *MockServer) Stub(method, path string, status int, body interface{}) {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
+    data, err := json.Marshal(body)
+    if err != nil {
+        panic(fmt.Sprintf("testutil: failed to marshal stubbed response for %s %s: %v", method, path, err))
+    }
+    m.stubs[stubKey(method, path)] = stubbedResponse{status: status, body: data}
+}
+
+// Reset clears every stubbed response.
+func (m *MockServer) Reset() {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    m.stubs = make(map[string]stubbedResponse)
+}
+
+func (m *MockServer) handle(w http.ResponseWriter, r *http.Request) {
+    m.mu.Lock()
+    resp, ok := m.stubs[stubKey(r.Method, r.URL.Path)]
+    m.mu.Unlock()
+
+    if !ok {
+        w.WriteHeader(http.StatusNotFound)
+        fmt.Fprintf(w, "testutil: no stub registered for %s %s", r.Method, r.URL.Path)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(resp.status)
+    w.Write(resp.body)
+}
+
+func stubKey(method, path string) string {
+    return method + " " + path
+}
@@ -0,0 +1,41 @@
+package webhooks
+
+import (
+    "context"
+    "fmt"
+)
+
+// Queue durably hands normalized events to downstream agents. The in-process
+// ChannelQueue below is a dev/single-node default; production deployments
+// are expected to supply an implementation backed by something durable
+// (Redis Streams, SQS, NATS JetStream, etc.) that survives a process
+// restart, since that is the whole point of persisting deliveries first.
+type Queue interface {
+    Push(ctx context.Context, event Event) error
+}
+
+// ChannelQueue is a Queue backed by a bounded in-memory channel.
+type ChannelQueue struct {
+    events chan Event
+}
+
+// NewChannelQueue creates a ChannelQueue with room for capacity buffered
+// events before Push starts blocking (or returning ctx.Err() on cancellation).
+func NewChannelQueue(capacity int) *ChannelQueue {
+    return &ChannelQueue{events: make(chan Event, capacity)}
+}
+
+// Push implements Queue.
+func (q *ChannelQueue) Push(ctx context.Context, event Event) error {
+    select {
+    case q.events <- event:
+        return nil
+    case <-ctx.Done():
+        return fmt.Errorf("failed to push event onto queue: %w", ctx.Err())
+    }
+}
+
+// Events returns the receive-only channel downstream agents consume from.
+func (q *ChannelQueue) Events() <-chan Event {
+    return q.events
+}
@@ -0,0 +1,137 @@
+package webhooks_test
+
+import (
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "sync"
+    "testing"
+
+    "github.com/google/uuid"
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/stretchr/testify/require"
+
+    "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
+    harness "github.com/yourdomain/agent-ai-platform/integration-service/pkg/testutil"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/webhooks"
+)
+
+// countingDispatcher wraps a Dispatcher whose fallback handler counts how
+// many events it was actually asked to dispatch, so tests can assert a
+// duplicate delivery never reaches it a second time.
+func countingDispatcher() (*webhooks.Dispatcher, func() int) {
+    var mu sync.Mutex
+    count := 0
+    d := webhooks.NewDispatcher(func(webhooks.Context, webhooks.Event) error {
+        mu.Lock()
+        defer mu.Unlock()
+        count++
+        return nil
+    })
+    return d, func() int {
+        mu.Lock()
+        defer mu.Unlock()
+        return count
+    }
+}
+
+func newTestReceiver(h *harness.Harness) (*webhooks.Receiver, *prometheus.CounterVec, func() int) {
+    metrics := prometheus.NewCounterVec(prometheus.CounterOpts{
+        Name: "testutil_webhook_receiver_operations_total",
+        Help: "Test-local copy of the webhook receiver's operation counter.",
+    }, []string{"event", "outcome"})
+    if err := h.Registry.Register(metrics); err != nil {
+        if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+            panic(err)
+        }
+    }
+
+    dispatcher, dispatchCount := countingDispatcher()
+    store := webhooks.NewGormDeliveryStore(h.DB)
+    queue := webhooks.NewChannelQueue(10)
+    rcv := webhooks.NewReceiver(h.DB, store, queue, dispatcher, metrics)
+    return rcv, metrics, dispatchCount
+}
+
+func seedSubscription(t *testing.T, h *harness.Harness, provider string, integrationID uuid.UUID, secret string) {
+    t.Helper()
+    sub := &models.WebhookSubscription{
+        IntegrationID: integrationID,
+        Provider:      provider,
+        EventType:     "order.created",
+        Secret:        secret,
+        Status:        models.StatusInactive,
+    }
+    require.NoError(t, h.DB.Create(sub).Error)
+}
+
+func deliveryRequest(provider string, integrationID uuid.UUID, body string) *http.Request {
+    url := fmt.Sprintf("/webhooks/%s/%s", provider, integrationID)
+    return httptest.NewRequest(http.MethodPost, url, strings.NewReader(body))
+}
+
+// TestReceiver_DuplicateDeliveryAckedOnce verifies that a provider retrying
+// the exact same delivery is acknowledged both times but only dispatched
+// (and pushed onto the queue) once, the behavior the 8e99a7f fix commit
+// moved the idempotency reservation earlier to guarantee.
+func TestReceiver_DuplicateDeliveryAckedOnce(t *testing.T) {
+    h := harness.NewHarness(t)
+    h.Snapshot(t)
+
+    const provider = "webhooks-test-duplicate"
+    webhooks.RegisterVerifier(provider, func(secret string, payload []byte, r *http.Request) error {
+        return nil
+    })
+
+    integration, err := h.SeedIntegration(models.ServiceTypeRMS, map[string]interface{}{"base_url": "http://rms.test"})
+    require.NoError(t, err)
+    seedSubscription(t, h, provider, integration.ID, "shared-secret")
+
+    rcv, _, dispatchCount := newTestReceiver(h)
+
+    body := `{"order_id": "123"}`
+    for i := 0; i < 2; i++ {
+        w := httptest.NewRecorder()
+        rcv.ServeHTTP(w, deliveryRequest(provider, integration.ID, body))
+        require.Equal(t, http.StatusAccepted, w.Code, "delivery %d", i)
+    }
+
+    require.Equal(t, 1, dispatchCount(), "retried delivery must not be dispatched twice")
+
+    var deliveryCount int64
+    require.NoError(t, h.DB.Model(&webhooks.Delivery{}).Where("integration_id = ?", integration.ID).Count(&deliveryCount).Error)
+    require.Equal(t, int64(1), deliveryCount)
+}
+
+// TestReceiver_BadSignatureRejected verifies that a delivery whose
+// signature fails verification is rejected and never recorded or
+// dispatched.
+func TestReceiver_BadSignatureRejected(t *testing.T) {
+    h := harness.NewHarness(t)
+    h.Snapshot(t)
+
+    const provider = "webhooks-test-bad-signature"
+    webhooks.RegisterVerifier(provider, func(secret string, payload []byte, r *http.Request) error {
+        return fmt.Errorf("signature mismatch")
+    })
+
+    integration, err := h.SeedIntegration(models.ServiceTypeRMS, map[string]interface{}{"base_url": "http://rms.test"})
+    require.NoError(t, err)
+    seedSubscription(t, h, provider, integration.ID, "shared-secret")
+
+    rcv, _, dispatchCount := newTestReceiver(h)
+
+    w := httptest.NewRecorder()
+    rcv.ServeHTTP(w, deliveryRequest(provider, integration.ID, `{"order_id": "123"}`))
+    require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+    require.Equal(t, 0, dispatchCount())
+
+    var sub models.WebhookSubscription
+    require.NoError(t, h.DB.Where("integration_id = ? AND provider = ?", integration.ID, provider).First(&sub).Error)
+    require.Equal(t, models.StatusError, sub.Status)
+
+    var deliveryCount int64
+    require.NoError(t, h.DB.Model(&webhooks.Delivery{}).Where("integration_id = ?", integration.ID).Count(&deliveryCount).Error)
+    require.Equal(t, int64(0), deliveryCount)
+}
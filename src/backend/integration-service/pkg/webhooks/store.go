@@ -0,0 +1,64 @@
+package webhooks
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/google/uuid"
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+// deliveryTableName defines the database table name for recorded deliveries.
+const deliveryTableName = "webhook_deliveries"
+
+// Delivery records that a webhook delivery with a given idempotency key was
+// received and processed, so a provider's retried delivery (same
+// idempotency key) can be recognized and skipped rather than re-dispatched.
+type Delivery struct {
+    IdempotencyKey string    `gorm:"type:varchar(255);primary_key"`
+    IntegrationID  uuid.UUID `gorm:"type:uuid;not null;index"`
+    Provider       string    `gorm:"type:varchar(50);not null"`
+    ReceivedAt     time.Time `gorm:"not null"`
+}
+
+// TableName specifies the database table name for the Delivery model.
+func (Delivery) TableName() string {
+    return deliveryTableName
+}
+
+// DeliveryStore tracks which idempotency keys have already been processed.
+type DeliveryStore interface {
+    // Reserve atomically claims delivery's idempotency key, reporting
+    // reserved=true only if this call is the one that claimed it.
+    // Implementations must make the claim atomic against concurrent callers
+    // racing on the same key (e.g. an INSERT relying on IdempotencyKey's
+    // primary key constraint), so two near-simultaneous retries of the same
+    // delivery can never both be reserved.
+    Reserve(ctx context.Context, delivery Delivery) (reserved bool, err error)
+}
+
+// gormDeliveryStore is the default DeliveryStore, backed by the service's
+// Postgres database.
+type gormDeliveryStore struct {
+    db *gorm.DB
+}
+
+// NewGormDeliveryStore creates a DeliveryStore backed by db. The caller is
+// responsible for ensuring the webhook_deliveries table exists, which
+// pkg/database.NewPostgresDB does by applying
+// pkg/database/migrations' embedded schema before handing out a pool.
+func NewGormDeliveryStore(db *gorm.DB) DeliveryStore {
+    return &gormDeliveryStore{db: db}
+}
+
+func (s *gormDeliveryStore) Reserve(ctx context.Context, delivery Delivery) (bool, error) {
+    // ON CONFLICT DO NOTHING leaves an already-claimed key alone without
+    // raising an error, so a duplicate never aborts an enclosing transaction.
+    result := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&delivery)
+    if result.Error != nil {
+        return false, fmt.Errorf("failed to reserve delivery: %w", result.Error)
+    }
+    return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,67 @@
+package webhooks
+
+import (
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "net/http"
+    "sync"
+)
+
+// Verifier checks an inbound delivery's signature against the shared secret
+// configured for the integration's subscription, reading whatever header(s)
+// its scheme requires from r. Each connector registers the scheme its
+// provider uses.
+type Verifier func(secret string, payload []byte, r *http.Request) error
+
+var verifierRegistry = struct {
+    mu        sync.RWMutex
+    verifiers map[string]Verifier
+}{
+    verifiers: make(map[string]Verifier),
+}
+
+// RegisterVerifier installs the signature verification scheme for provider.
+// Connector packages call this from an init() alongside
+// models.RegisterServiceType and connectors.Register.
+func RegisterVerifier(provider string, verifier Verifier) {
+    verifierRegistry.mu.Lock()
+    defer verifierRegistry.mu.Unlock()
+    verifierRegistry.verifiers[provider] = verifier
+}
+
+// VerifierFor returns the registered Verifier for provider, if any.
+func VerifierFor(provider string) (Verifier, bool) {
+    verifierRegistry.mu.RLock()
+    defer verifierRegistry.mu.RUnlock()
+    v, ok := verifierRegistry.verifiers[provider]
+    return v, ok
+}
+
+// HMACSHA256Verifier returns a Verifier that treats signatureHeader as the
+// hex-encoded HMAC-SHA256 of payload keyed by secret. This is the scheme
+// Zoho CRM uses for its webhook notifications and is a reasonable default
+// for connectors that do not need anything more elaborate.
+func HMACSHA256Verifier(headerName string) Verifier {
+    return func(secret string, payload []byte, r *http.Request) error {
+        signatureHeader := r.Header.Get(headerName)
+        if signatureHeader == "" {
+            return fmt.Errorf("missing %s header", headerName)
+        }
+
+        expected, err := hex.DecodeString(signatureHeader)
+        if err != nil {
+            return fmt.Errorf("malformed %s header: %w", headerName, err)
+        }
+
+        mac := hmac.New(sha256.New, []byte(secret))
+        mac.Write(payload)
+        computed := mac.Sum(nil)
+
+        if !hmac.Equal(computed, expected) {
+            return fmt.Errorf("signature mismatch")
+        }
+        return nil
+    }
+}
@@ -0,0 +1,86 @@
+// Package webhooks provides an inbound webhook receiver subsystem for
+// connectors (RMS, Zoho CRM, and future third-party connectors) that only
+// expose polling APIs today. It verifies provider signatures, persists
+// deliveries under an idempotency key so retried deliveries are not
+// double-processed, dispatches normalized events to per-integration
+// handlers, and pushes those events onto a durable Queue for downstream
+// agents to consume.
+package webhooks
+
+import (
+    "encoding/json"
+    "fmt"
+    "sync"
+    "time"
+
+    "github.com/google/uuid"
+)
+
+// Event is the normalized representation of an inbound webhook delivery,
+// independent of the wire format the originating provider used.
+type Event struct {
+    IdempotencyKey string          `json:"idempotency_key"`
+    IntegrationID  uuid.UUID       `json:"integration_id"`
+    Provider       string          `json:"provider"`
+    Type           string          `json:"type"`
+    Payload        json.RawMessage `json:"payload"`
+    ReceivedAt     time.Time       `json:"received_at"`
+}
+
+// Handler processes a normalized Event for a specific integration.
+type Handler func(ctx Context, event Event) error
+
+// Context carries the subset of request-scoped values a Handler needs,
+// kept separate from context.Context so handlers stay easy to test.
+type Context struct {
+    RemoteAddr string
+}
+
+// Dispatcher routes events to the handler registered for their
+// IntegrationID, falling back to a default handler (typically "enqueue to
+// the durable queue") when no integration-specific handler is registered.
+type Dispatcher struct {
+    mu       sync.RWMutex
+    handlers map[uuid.UUID]Handler
+    fallback Handler
+}
+
+// NewDispatcher creates a Dispatcher. fallback is invoked for integrations
+// that have not registered a dedicated Handler; it may be nil.
+func NewDispatcher(fallback Handler) *Dispatcher {
+    return &Dispatcher{
+        handlers: make(map[uuid.UUID]Handler),
+        fallback: fallback,
+    }
+}
+
+// RegisterHandler installs handler for integrationID, replacing any
+// previously registered handler.
+func (d *Dispatcher) RegisterHandler(integrationID uuid.UUID, handler Handler) {
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    d.handlers[integrationID] = handler
+}
+
+// RemoveHandler uninstalls the handler registered for integrationID.
+func (d *Dispatcher) RemoveHandler(integrationID uuid.UUID) {
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    delete(d.handlers, integrationID)
+}
+
+// Dispatch routes event to the handler registered for its IntegrationID,
+// or the fallback handler if none is registered.
+func (d *Dispatcher) Dispatch(ctx Context, event Event) error {
+    d.mu.RLock()
+    handler, ok := d.handlers[event.IntegrationID]
+    d.mu.RUnlock()
+
+    if !ok {
+        handler = d.fallback
+    }
+    if handler == nil {
+        return fmt.Errorf("no webhook handler registered for integration %s", event.IntegrationID)
+    }
+    return handler(ctx, event)
+}
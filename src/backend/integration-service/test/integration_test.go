@@ -4,79 +4,33 @@ package test
 
 import (
     "context"
-    "encoding/json"
-    "fmt"
-    "os"
+    "net/http"
     "testing"
     "time"
 
-    "github.com/google/uuid"                                  // v1.3.0
     "github.com/stretchr/testify/assert"                     // v1.8.0
     "github.com/stretchr/testify/require"                    // v1.8.0
-    "github.com/testcontainers/testcontainers-go"            // v0.20.1
     "github.com/prometheus/client_golang/prometheus"          // v1.14.0
     "github.com/prometheus/client_golang/prometheus/testutil" // v1.14.0
-    "go.uber.org/zap"                                        // v1.24.0
 
-    "github.com/yourdomain/agent-ai-platform/integration-service/config"
     "github.com/yourdomain/agent-ai-platform/integration-service/internal/models"
     "github.com/yourdomain/agent-ai-platform/integration-service/internal/services/zoho"
     "github.com/yourdomain/agent-ai-platform/integration-service/pkg/auth"
     "github.com/yourdomain/agent-ai-platform/integration-service/pkg/database"
+    "github.com/yourdomain/agent-ai-platform/integration-service/pkg/reliability"
+    harness "github.com/yourdomain/agent-ai-platform/integration-service/pkg/testutil"
 )
 
-var (
-    testDB          *gorm.DB
-    testConfig      *config.Config
-    metricsRegistry *prometheus.Registry
-    logger          *zap.Logger
-    containers      []testcontainers.Container
-)
-
-// TestMain sets up the test environment with necessary dependencies
-func TestMain(m *testing.M) {
-    var err error
-    ctx := context.Background()
-
-    // Initialize logger
-    logger, err = zap.NewDevelopment()
-    if err != nil {
-        fmt.Printf("Failed to initialize logger: %v\n", err)
-        os.Exit(1)
-    }
-
-    // Initialize metrics registry
-    metricsRegistry = prometheus.NewRegistry()
-
-    // Start test containers
-    containers, testConfig, err = setupTestContainers(ctx)
-    if err != nil {
-        logger.Fatal("Failed to setup test containers", zap.Error(err))
-    }
-
-    // Initialize test database
-    testDB, err = setupTestDatabase(testConfig)
-    if err != nil {
-        logger.Fatal("Failed to setup test database", zap.Error(err))
-    }
-
-    // Run tests
-    code := m.Run()
-
-    // Cleanup
-    if err := cleanup(ctx); err != nil {
-        logger.Error("Cleanup failed", zap.Error(err))
-    }
-
-    os.Exit(code)
-}
-
 // TestZohoCRMIntegrationE2E tests the complete Zoho CRM integration flow
+// against testutil.Harness's mock Zoho server, instead of each test file
+// re-deriving its own container lifecycle and database setup.
 func TestZohoCRMIntegrationE2E(t *testing.T) {
     ctx := context.Background()
     assert := assert.New(t)
     require := require.New(t)
 
+    h := harness.NewHarness(t)
+
     // Initialize metrics
     integrationMetrics := prometheus.NewCounterVec(
         prometheus.CounterOpts{
@@ -85,35 +39,36 @@ func TestZohoCRMIntegrationE2E(t *testing.T) {
         },
         []string{"operation", "status"},
     )
-    require.NoError(metricsRegistry.Register(integrationMetrics))
-
-    // Create test integration
-    integration := &models.Integration{
-        ID:          uuid.New(),
-        AgentID:     uuid.New(),
-        Name:        "Test Zoho Integration",
-        ServiceType: models.ServiceTypeZohoCRM,
-        Status:      models.StatusInactive,
-        Config: json.RawMessage(`{
-            "client_id": "test_client_id",
-            "client_secret": "test_client_secret",
-            "refresh_token": "test_refresh_token"
-        }`),
-    }
-
-    // Test integration creation
-    err := testDB.Create(integration).Error
-    require.NoError(err, "Failed to create test integration")
-
-    // Initialize OAuth manager
-    authManager, err := auth.NewOAuthManager(testConfig, testDB, nil, logger)
+    require.NoError(h.Registry.Register(integrationMetrics))
+
+    integration, err := h.SeedIntegration(models.ServiceTypeZohoCRM, map[string]interface{}{
+        "client_id":     "test_client_id",
+        "client_secret": "test_client_secret",
+        "refresh_token": "test_refresh_token",
+    })
+    require.NoError(err, "Failed to seed test integration")
+
+    // Initialize OAuth manager. The harness's transaction-backed h.DB never
+    // rotates, so it's wrapped in a non-rotating AtomicDB rather than a full
+    // database.CredentialManager.
+    atomicDB := database.NewStaticAtomicDB(h.DB)
+    authManager, err := auth.NewOAuthManager(h.Config, atomicDB, nil, h.Logger)
     require.NoError(err, "Failed to create OAuth manager")
 
     // Initialize Zoho client
-    zohoClient := zoho.NewZohoClient(authManager, integration.ID, integrationMetrics, logger)
+    zohoClient := zoho.NewZohoClient(auth.NewOAuthAuthenticator(authManager, integration.ID), integration.ID, atomicDB, integrationMetrics, h.Logger, reliability.Config{})
+
+    h.ZohoMock().Stub(http.MethodPost, "/Leads", http.StatusCreated, map[string]interface{}{
+        "data": []map[string]interface{}{{"id": "lead-1"}},
+    })
+    h.ZohoMock().Stub(http.MethodGet, "/Leads", http.StatusOK, map[string]interface{}{
+        "data": []map[string]interface{}{{"id": "lead-1", "name": "Test Lead", "email": "test@example.com"}},
+    })
 
     // Test record creation
     t.Run("CreateRecord", func(t *testing.T) {
+        h.Snapshot(t)
+
         record := map[string]interface{}{
             "name": "Test Lead",
             "email": "test@example.com",
@@ -134,6 +89,8 @@ func TestZohoCRMIntegrationE2E(t *testing.T) {
 
     // Test record retrieval
     t.Run("GetRecords", func(t *testing.T) {
+        h.Snapshot(t)
+
         ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
         defer cancel()
 
@@ -152,6 +109,8 @@ func TestZohoCRMIntegrationE2E(t *testing.T) {
 
     // Test error handling
     t.Run("ErrorHandling", func(t *testing.T) {
+        h.Snapshot(t)
+
         ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
         defer cancel()
 
@@ -166,6 +125,8 @@ func TestZohoCRMIntegrationE2E(t *testing.T) {
 
     // Test performance requirements
     t.Run("PerformanceValidation", func(t *testing.T) {
+        h.Snapshot(t)
+
         ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
         defer cancel()
 
@@ -179,6 +140,8 @@ func TestZohoCRMIntegrationE2E(t *testing.T) {
 
     // Test OAuth token refresh
     t.Run("TokenRefresh", func(t *testing.T) {
+        h.Snapshot(t)
+
         ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
         defer cancel()
 
@@ -193,24 +156,4 @@ func TestZohoCRMIntegrationE2E(t *testing.T) {
 func TestRMSIntegrationE2E(t *testing.T) {
     // Similar structure to Zoho CRM test, implementing RMS-specific tests
     // Implementation omitted for brevity but follows same patterns
-}
-
-// Helper functions
-
-func setupTestContainers(ctx context.Context) ([]testcontainers.Container, *config.Config, error) {
-    // Implementation omitted for brevity
-    // Sets up PostgreSQL, Redis, and mock external service containers
-    return nil, nil, nil
-}
-
-func setupTestDatabase(cfg *config.Config) (*gorm.DB, error) {
-    // Implementation omitted for brevity
-    // Initializes test database with required schema
-    return nil, nil
-}
-
-func cleanup(ctx context.Context) error {
-    // Implementation omitted for brevity
-    // Cleans up test containers and resources
-    return nil
 }
\ No newline at end of file